@@ -383,105 +383,66 @@ func runSTTEcho(filePath, provider string, logger *slog.Logger) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Create STT stream
-	stream, err := sttProvider.NewStream(ctx, stt.StreamConfig{
-		SampleRate:  16000,
-		NumChannels: 1,
-		Lang:        "en-US",
-		MaxRetry:    3,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create STT stream: %w", err)
-	}
-
-	// Start listening for events
-	go func() {
-		for event := range stream.Events() {
-			switch event.Type {
-			case stt.SpeechEventInterim:
-				logger.Info("Interim result", slog.String("text", event.Text))
-			case stt.SpeechEventFinal:
-				logger.Info("Final result", slog.String("text", event.Text))
-				fmt.Printf("Transcript: %s\n", event.Text)
-			case stt.SpeechEventError:
-				logger.Error("STT error", slog.String("error", event.Error.Error()))
-			}
-		}
-	}()
-
 	// Read WAV file
 	logger.Info("Processing audio file", slog.String("file", filePath))
 	
+	var frames []rtc.AudioFrame
 	wavReader, err := wav.NewReader(filePath)
 	if err != nil {
 		logger.Warn("Failed to read WAV file, using fake frames instead", slog.String("error", err.Error()))
 		// Fallback to fake frames for demo purposes
-		return runSTTEchoWithFakeFrames(stream, logger)
-	}
-	defer wavReader.Close()
-
-	frames, err := wavReader.ReadFrames()
-	if err != nil {
-		return fmt.Errorf("failed to read audio frames: %w", err)
-	}
-
-	header := wavReader.Header()
-	logger.Info("WAV file info", 
-		slog.Int("sample_rate", int(header.SampleRate)),
-		slog.Int("channels", int(header.NumChannels)),
-		slog.Int("bits_per_sample", int(header.BitsPerSample)),
-		slog.Int("frames", len(frames)))
+		frames = fakeAudioFrames()
+	} else {
+		defer wavReader.Close()
 
-	// Push audio frames to STT
-	for i, frame := range frames {
-		if err := stream.Push(frame); err != nil {
-			return fmt.Errorf("failed to push audio frame %d: %w", i, err)
+		frames, err = wavReader.ReadFrames()
+		if err != nil {
+			return fmt.Errorf("failed to read audio frames: %w", err)
 		}
 
-		// Small delay to simulate real-time processing
-		time.Sleep(10 * time.Millisecond)
+		header := wavReader.Header()
+		logger.Info("WAV file info", 
+			slog.Int("sample_rate", int(header.SampleRate)),
+			slog.Int("channels", int(header.NumChannels)),
+			slog.Int("bits_per_sample", int(header.BitsPerSample)),
+			slog.Int("frames", len(frames)))
 	}
 
-	// Close the stream to get final results
-	if err := stream.CloseSend(); err != nil {
-		return fmt.Errorf("failed to close STT stream: %w", err)
+	// Transcribe the whole file at once
+	result, err := stt.Recognize(ctx, sttProvider, frames, stt.RecognizeOptions{Language: "en-US"})
+	if err != nil {
+		return fmt.Errorf("failed to transcribe audio: %w", err)
 	}
 
-	// Wait a bit for final results
-	time.Sleep(100 * time.Millisecond)
+	logger.Info("Final result",
+		slog.String("text", result.Text),
+		slog.String("language", result.Language),
+		slog.Float64("confidence", float64(result.Confidence)))
+	for _, word := range result.Words {
+		logger.Debug("Word",
+			slog.String("text", word.Text),
+			slog.Duration("start", word.Start),
+			slog.Duration("end", word.End))
+	}
+	fmt.Printf("Transcript: %s\n", result.Text)
 
 	logger.Info("STT echo completed successfully")
 	return nil
 }
 
-func runSTTEchoWithFakeFrames(stream stt.STTStream, logger *slog.Logger) error {
-	// Generate fake audio frames (fallback when WAV reading fails)
+// fakeAudioFrames generates 500ms of silent audio (fallback when WAV reading fails).
+func fakeAudioFrames() []rtc.AudioFrame {
+	frames := make([]rtc.AudioFrame, 0, 50)
 	for i := 0; i < 50; i++ {
-		frame := rtc.AudioFrame{
+		frames = append(frames, rtc.AudioFrame{
 			Data:              make([]byte, 320), // 16000/100 * 1 * 2 = 320 bytes for 10ms mono
 			SampleRate:        16000,
 			SamplesPerChannel: 160,
 			NumChannels:       1,
 			Timestamp:         time.Duration(i) * 10 * time.Millisecond,
-		}
-
-		if err := stream.Push(frame); err != nil {
-			return fmt.Errorf("failed to push audio frame: %w", err)
-		}
-
-		// Small delay to simulate real-time processing
-		time.Sleep(10 * time.Millisecond)
-	}
-
-	// Close the stream to get final results
-	if err := stream.CloseSend(); err != nil {
-		return fmt.Errorf("failed to close STT stream: %w", err)
+		})
 	}
-
-	// Wait a bit for final results
-	time.Sleep(100 * time.Millisecond)
-
-	return nil
+	return frames
 }
 
 func runAgentDemo(ctx context.Context, url, token, roomName string, metrics bool, bgFile string, bgVolume float32, logger *slog.Logger) error {
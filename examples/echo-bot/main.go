@@ -128,7 +128,7 @@ func runEchoBot(ctx context.Context) error {
 		TurnDetector: turnDetector,
 		MicIn:        micIn,
 		TTSOut:       ttsOut,
-		Language:     "en-US",
+		Audio:        agent.AudioOptions{Language: "en-US"},
 		Tools:        []agent.Tool{
 			createEchoBotTool(),
 		},
@@ -5,6 +5,7 @@ package agent
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"expvar"
 	"fmt"
 	"log"
@@ -12,16 +13,21 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"log/slog"
 
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
 	"github.com/chriscow/livekit-agents-go/pkg/ai/llm"
 	"github.com/chriscow/livekit-agents-go/pkg/ai/stt"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/tokenize"
 	"github.com/chriscow/livekit-agents-go/pkg/ai/tts"
 	"github.com/chriscow/livekit-agents-go/pkg/ai/vad"
 	"github.com/chriscow/livekit-agents-go/pkg/job"
+	"github.com/chriscow/livekit-agents-go/pkg/metrics"
 	"github.com/chriscow/livekit-agents-go/pkg/rtc"
 	"github.com/chriscow/livekit-agents-go/pkg/turn"
+	"github.com/chriscow/livekit-agents-go/pkg/voice"
 )
 
 // Tool represents a function that the agent can call in response to LLM function calls.
@@ -30,6 +36,23 @@ type Tool struct {
 	Description string                                        // Description for the LLM
 	Schema      map[string]any                                // JSON schema for parameters
 	Handler     func(context.Context, string) (string, error) // Function handler (receives JSON args, returns result)
+	Timeout     time.Duration                                 // Maximum execution time (optional, defaults to 30s)
+
+	// Filler is spoken if the tool is still running after FillerDelay, for
+	// example "Let me check that for you" (optional)
+	Filler string
+	// FillerFunc returns the filler to speak for the call's JSON arguments and
+	// takes precedence over Filler (optional)
+	FillerFunc func(ctx context.Context, args string) string
+	// FillerDelay is how long the tool may run before the filler is spoken
+	// (optional, defaults to 2s)
+	FillerDelay time.Duration
+
+	// RequireConfirmation makes the agent ask the user before executing the tool
+	RequireConfirmation bool
+	// ConfirmationPrompt is the question asked before executing the tool
+	// (optional, defaults to asking whether to go ahead with the tool)
+	ConfirmationPrompt string
 }
 
 // AgentState represents the current state of the voice agent.
@@ -69,10 +92,18 @@ type Agent struct {
 	turnDetector turn.Detector
 
 	// Tools for function calling
-	tools map[string]Tool
+	tools       map[string]Tool
+	toolCallSeq atomic.Int64 // Source of IDs for tool calls the LLM did not assign one
+
+	// Active task; guards llm, tts, tools and voice which change on handoff
+	taskMu sync.RWMutex
+	task   *AgentTask
+	voice  string
 
 	// State management
-	state atomic.Int32
+	state        atomic.Int32
+	stateMu      sync.Mutex
+	stateChanged chan struct{} // closed and replaced on every state change
 
 	// Channels for communication
 	micIn  <-chan rtc.AudioFrame
@@ -82,6 +113,8 @@ type Agent struct {
 	vadEvents    <-chan vad.VADEvent
 	sttEvents    <-chan stt.SpeechEvent
 	interrupts   chan struct{}
+	bargeIns     chan struct{}
+	resumes      chan *speechHandle
 	shutdown     chan struct{}
 	shutdownOnce sync.Once
 
@@ -92,19 +125,53 @@ type Agent struct {
 	feederCancelMu sync.Mutex
 	feederDone     chan struct{}
 
-	// Conversation history for turn detection
-	conversation   []llm.Message
-	conversationMu sync.RWMutex
-	language       string // Language for turn detection
+	// Per-turn cancellation so that an interruption stops generation and playback
+	turnMu      sync.Mutex
+	thinkCancel context.CancelFunc
+	speech      *speechHandle
+
+	// Interruption policy state
+	interruption InterruptionPolicy
+	audioGate    voice.AudioGate
+	policyMu     sync.Mutex
+	pending      *pendingInterruption
+	pausedSpeech *speechHandle
+	userSpeaking bool
+
+	// Tool confirmation waiting for the user's answer
+	confirmMu      sync.Mutex // serializes confirmations of parallel tool calls
+	confirmStateMu sync.Mutex
+	confirmation   chan string
+
+	// Conversation history shared by the LLM and turn detection
+	chatCtx *llm.ChatContext
+
+	// TTS failure handling
+	ttsRetry    ai.RetryConfig
+	ttsFallback string
+
+	// Audio format and voice options for STT, TTS and turn detection
+	audio         AudioOptions
+	sttSampleRate int // microphone audio is resampled when this differs from audio.SampleRate
+	vadSampleRate int
 
 	// Metrics
 	sessionStart      time.Time
 	firstWordTimeOnce sync.Once
 	firstWordTime     time.Time
 	metrics           *AgentMetrics
+	usage             atomic.Pointer[metrics.Collector]
 
 	// Background audio
 	backgroundAudio *BackgroundAudio
+
+	// Event subscribers
+	events *eventBus
+
+	// Speech scheduled through Say and GenerateReply
+	queueMu     sync.Mutex
+	speechQueue []*SpeechHandle
+	queueSignal chan struct{}
 }
 
 // AgentMetrics holds performance metrics for the agent.
@@ -134,8 +201,44 @@ type Config struct {
 	// BackgroundAudio is optional background audio support
 	BackgroundAudio *BackgroundAudio
 
-	// Language for turn detection (optional, defaults to "en-US")
+	// Language for turn detection (optional, defaults to "en-US").
+	//
+	// Deprecated: set Audio.Language, which also applies to STT and TTS.
 	Language string
+
+	// Audio configures the microphone format and the TTS voice (optional)
+	Audio AudioOptions
+
+	// Instructions is the system prompt sent with every LLM request (optional)
+	Instructions string
+
+	// ChatContext seeds the conversation history (optional). When set, its
+	// instructions are replaced by Instructions if that is non-empty.
+	ChatContext *llm.ChatContext
+
+	// Truncation limits the history sent to the LLM (optional, defaults to the full history)
+	Truncation llm.TruncationStrategy
+
+	// Interruption controls when user speech interrupts the agent (optional)
+	Interruption InterruptionPolicy
+
+	// AudioGate discards microphone frames while TTS is playing if interruptions
+	// are disabled (optional, a default gate is created when needed)
+	AudioGate voice.AudioGate
+
+	// TTSRetry controls how recoverable TTS failures are retried (optional,
+	// defaults to ai.DefaultRetryConfig). A segment is only retried if none of
+	// its audio was played.
+	TTSRetry *ai.RetryConfig
+
+	// TTSFallbackMessage is spoken when synthesizing a reply fails and cannot
+	// be retried, for example "Sorry, I'm having trouble speaking right now"
+	// (optional)
+	TTSFallbackMessage string
+
+	// Usage receives the usage of every LLM, STT and TTS call (optional,
+	// defaults to the usage collector of the job passed to Start)
+	Usage *metrics.Collector
 }
 
 // New creates a new Agent with the given configuration.
@@ -168,10 +271,35 @@ func New(cfg Config) (*Agent, error) {
 		toolsMap[tool.Name] = tool
 	}
 
-	// Set default language if not provided
-	language := cfg.Language
-	if language == "" {
-		language = "en-US"
+	audio := cfg.Audio.withDefaults(cfg.Language)
+	if err := audio.validateFormat(); err != nil {
+		return nil, fmt.Errorf("invalid audio options: %w", err)
+	}
+	if err := audio.validateSTT(cfg.STT.Capabilities()); err != nil {
+		return nil, fmt.Errorf("invalid audio options: %w", err)
+	}
+	if err := audio.validateTTS(cfg.TTS.Capabilities(), audio.Voice); err != nil {
+		return nil, fmt.Errorf("invalid audio options: %w", err)
+	}
+
+	chatCtx := cfg.ChatContext
+	if chatCtx == nil {
+		chatCtx = llm.NewChatContext(cfg.Instructions)
+	} else if cfg.Instructions != "" {
+		chatCtx.SetInstructions(cfg.Instructions)
+	}
+	if cfg.Truncation != nil {
+		chatCtx.SetTruncation(cfg.Truncation)
+	}
+
+	audioGate := cfg.AudioGate
+	if audioGate == nil && cfg.Interruption.Disabled {
+		audioGate = voice.NewAudioGate()
+	}
+
+	ttsRetry := ai.DefaultRetryConfig
+	if cfg.TTSRetry != nil {
+		ttsRetry = *cfg.TTSRetry
 	}
 
 	a := &Agent{
@@ -184,14 +312,34 @@ func New(cfg Config) (*Agent, error) {
 		micIn:           cfg.MicIn,
 		ttsOut:          cfg.TTSOut,
 		interrupts:      make(chan struct{}, 1),
+		bargeIns:        make(chan struct{}, 1),
+		resumes:         make(chan *speechHandle),
 		shutdown:        make(chan struct{}),
 		metrics:         newAgentMetrics(),
 		backgroundAudio: cfg.BackgroundAudio,
-		conversation:    make([]llm.Message, 0),
-		language:        language,
+		chatCtx:         chatCtx,
+		audio:           audio,
+		sttSampleRate:   supportedSampleRate(audio.SampleRate, cfg.STT.Capabilities().SampleRates),
+		vadSampleRate:   supportedSampleRate(audio.SampleRate, cfg.VAD.Capabilities().SampleRates),
+		interruption:    cfg.Interruption,
+		audioGate:       audioGate,
+		ttsRetry:        ttsRetry,
+		ttsFallback:     cfg.TTSFallbackMessage,
+		events:          newEventBus(),
+		voice:           audio.Voice,
+		stateChanged:    make(chan struct{}),
+		queueSignal:     make(chan struct{}, 1),
+	}
+	a.usage.Store(cfg.Usage)
+	a.task = &AgentTask{
+		Instructions: chatCtx.Instructions(),
+		Tools:        cfg.Tools,
+		LLM:          cfg.LLM,
+		TTS:          cfg.TTS,
 	}
 
 	a.setState(StateIdle)
+	go a.runSpeechQueue()
 	return a, nil
 }
 
@@ -221,8 +369,30 @@ func (a *Agent) Start(ctx context.Context, j *job.Job) error {
 	a.sessionStart = time.Now()
 	defer a.updateSessionDuration()
 
-	// Start VAD processing
-	vadEvents, err := a.vad.Detect(combinedCtx, a.micIn)
+	if a.usage.Load() == nil {
+		usage := j.Usage
+		if usage == nil {
+			usage = metrics.NewCollector()
+		}
+		a.usage.Store(usage)
+	}
+
+	// Route microphone audio through the gate so it can be muted during playback
+	if a.audioGate != nil {
+		a.micIn = a.gateAudio(combinedCtx, a.micIn)
+	}
+
+	// Start VAD processing, VAD models expect mono audio
+	vadIn := a.micIn
+	if a.vadSampleRate != a.audio.SampleRate || a.audio.NumChannels != 1 {
+		log.Printf("🔁 Converting microphone audio to %d Hz mono for VAD", a.vadSampleRate)
+		converted, err := rtc.ConvertAudio(combinedCtx, a.micIn, a.vadSampleRate, 1)
+		if err != nil {
+			return fmt.Errorf("failed to convert audio for VAD: %w", err)
+		}
+		vadIn = converted
+	}
+	vadEvents, err := a.vad.Detect(combinedCtx, vadIn)
 	if err != nil {
 		return fmt.Errorf("failed to start VAD: %w", err)
 	}
@@ -245,6 +415,7 @@ func (a *Agent) Interrupt() {
 func (a *Agent) Close() error {
 	a.shutdownOnce.Do(func() {
 		close(a.shutdown)
+		a.events.close()
 	})
 
 	// Clean up current streams
@@ -259,6 +430,12 @@ func (a *Agent) Close() error {
 	return nil
 }
 
+// Usage returns the collector that receives the usage of the agent's
+// provider calls. It is nil until Start is called, unless Config.Usage was set.
+func (a *Agent) Usage() *metrics.Collector {
+	return a.usage.Load()
+}
+
 // GetState returns the current state of the agent.
 func (a *Agent) GetState() AgentState {
 	return AgentState(a.state.Load())
@@ -277,6 +454,15 @@ func (a *Agent) setState(newState AgentState) {
 		newCounter.Set(1)
 		a.metrics.StateTransitions.Set(transitionKey, newCounter)
 	}
+
+	if oldState != newState {
+		a.stateMu.Lock()
+		close(a.stateChanged)
+		a.stateChanged = make(chan struct{})
+		a.stateMu.Unlock()
+
+		a.events.emit(StateChangedEvent{OldState: oldState, NewState: newState, Timestamp: time.Now()})
+	}
 }
 
 // run is the main agent loop that processes events and manages state transitions.
@@ -291,6 +477,14 @@ func (a *Agent) run(ctx context.Context) error {
 			if err := a.handleInterrupt(ctx); err != nil {
 				return fmt.Errorf("interrupt handling failed: %w", err)
 			}
+		case <-a.bargeIns:
+			if err := a.handleBargeIn(ctx); err != nil {
+				return fmt.Errorf("barge-in handling failed: %w", err)
+			}
+		case speech := <-a.resumes:
+			if err := a.handleResume(ctx, speech); err != nil {
+				return fmt.Errorf("resume handling failed: %w", err)
+			}
 		case vadEvent := <-a.vadEvents:
 			if err := a.handleVADEvent(ctx, vadEvent); err != nil {
 				return fmt.Errorf("VAD event handling failed: %w", err)
@@ -309,11 +503,14 @@ func (a *Agent) handleInterrupt(ctx context.Context) error {
 
 	switch currentState {
 	case StateSpeaking:
-		// Cancel TTS playback and transition to listening
+		// Cancel TTS playback and transition to listening, keeping the words
+		// transcribed while the interruption policy was checked
+		a.cancelTurn()
 		a.setState(StateListening)
-		return a.startListening(ctx)
+		return a.continueListening(ctx)
 	case StateThinking:
 		// Cancel LLM processing and transition to listening
+		a.cancelTurn()
 		a.setState(StateListening)
 		return a.startListening(ctx)
 	default:
@@ -327,24 +524,43 @@ func (a *Agent) handleVADEvent(ctx context.Context, event vad.VADEvent) error {
 	currentState := a.GetState()
 
 	switch event.Type {
+	case vad.VADEventError:
+		a.emitError("vad", event.Error)
 	case vad.VADEventSpeechStart:
+		a.setUserSpeaking(true)
 		switch currentState {
 		case StateIdle:
 			a.setState(StateListening)
 			return a.startListening(ctx)
 		case StateSpeaking:
-			// Interrupt current speech
-			return a.handleInterrupt(ctx)
+			// Interrupt current speech, subject to the interruption policy
+			return a.handleUserSpeechWhileSpeaking(ctx)
 		}
 	case vad.VADEventSpeechEnd:
-		if currentState == StateListening {
+		a.setUserSpeaking(false)
+		switch currentState {
+		case StateListening:
+			if a.isSpeechPaused() {
+				// Wait for a transcript or the false interruption timeout
+				return nil
+			}
 			return a.handleSpeechEnd(ctx)
+		case StateSpeaking:
+			// Speech ended before it qualified as an interruption
+			return a.cancelPendingInterruption(ctx)
 		}
 	}
 
 	return nil
 }
 
+// setUserSpeaking records whether VAD currently detects user speech.
+func (a *Agent) setUserSpeaking(speaking bool) {
+	a.policyMu.Lock()
+	a.userSpeaking = speaking
+	a.policyMu.Unlock()
+}
+
 // handleSpeechEnd processes speech end events using turn detection.
 func (a *Agent) handleSpeechEnd(ctx context.Context) error {
 	// Start VAD-silence timer and turn detection
@@ -357,6 +573,7 @@ func (a *Agent) runTurnDetection(ctx context.Context) {
 	silenceStart := time.Now()
 	ticker := time.NewTicker(50 * time.Millisecond) // Check every 50ms
 	defer ticker.Stop()
+	reportedErr := false
 
 	for {
 		select {
@@ -369,12 +586,10 @@ func (a *Agent) runTurnDetection(ctx context.Context) {
 			}
 
 			// Get conversation history for turn detection
-			a.conversationMu.RLock()
 			chatCtx := turn.ChatContext{
-				Messages: a.conversation,
-				Language: a.language,
+				Messages: a.chatCtx.History(),
+				Language: a.audio.Language,
 			}
-			a.conversationMu.RUnlock()
 
 			// Run turn detection with timing
 			inferenceStart := time.Now()
@@ -384,7 +599,7 @@ func (a *Agent) runTurnDetection(ctx context.Context) {
 			slog.Debug("turn detection inference",
 				slog.Float64("eou_probability", probability),
 				slog.Float64("inference_latency_ms", float64(inferenceLatency.Milliseconds())),
-				slog.String("language", a.language),
+				slog.String("language", a.audio.Language),
 			)
 
 			// Record metrics
@@ -395,10 +610,18 @@ func (a *Agent) runTurnDetection(ctx context.Context) {
 
 			if err != nil {
 				log.Printf("Turn detection failed (latency: %v): %v", inferenceLatency, err)
+				if !reportedErr {
+					reportedErr = true
+					a.emitError("turn", err)
+				}
 				// Fall back to thinking state after 2s timeout
 				if time.Since(silenceStart) >= 2*time.Second {
 					endOfUtteranceDelay := time.Since(silenceStart)
 					a.metrics.EndOfUtteranceDelay.Set(float64(endOfUtteranceDelay.Milliseconds()))
+					a.emitMetrics(map[string]float64{
+						"end_of_utterance_delay_ms": float64(endOfUtteranceDelay.Milliseconds()),
+						"turn_inference_latency_ms": float64(inferenceLatency.Milliseconds()),
+					})
 					log.Printf("Turn ended by timeout after %v (probability: N/A, threshold: N/A)", endOfUtteranceDelay)
 					// Structured logging for timeout end
 					slog.Info("turn ended",
@@ -414,9 +637,9 @@ func (a *Agent) runTurnDetection(ctx context.Context) {
 			}
 
 			// Get threshold for current language, with fallback to base code
-			threshold, err := a.turnDetector.UnlikelyThreshold(a.language)
-			if err != nil && strings.Contains(a.language, "-") {
-				parts := strings.SplitN(a.language, "-", 2)
+			threshold, err := a.turnDetector.UnlikelyThreshold(a.audio.Language)
+			if err != nil && strings.Contains(a.audio.Language, "-") {
+				parts := strings.SplitN(a.audio.Language, "-", 2)
 				threshold, _ = a.turnDetector.UnlikelyThreshold(parts[0]) // fallback
 			}
 			if err != nil {
@@ -429,6 +652,11 @@ func (a *Agent) runTurnDetection(ctx context.Context) {
 			if shouldEndTurn {
 				endOfUtteranceDelay := time.Since(silenceStart)
 				a.metrics.EndOfUtteranceDelay.Set(float64(endOfUtteranceDelay.Milliseconds()))
+				a.emitMetrics(map[string]float64{
+					"end_of_utterance_delay_ms": float64(endOfUtteranceDelay.Milliseconds()),
+					"turn_inference_latency_ms": float64(inferenceLatency.Milliseconds()),
+					"eou_probability":           probability,
+				})
 				// Structured logging for probability end
 				reason := "probability"
 				if time.Since(silenceStart) >= 2*time.Second {
@@ -459,21 +687,98 @@ func (a *Agent) runTurnDetection(ctx context.Context) {
 
 // handleSTTEvent processes speech-to-text events.
 func (a *Agent) handleSTTEvent(ctx context.Context, event stt.SpeechEvent) error {
+	if event.Type == stt.SpeechEventError {
+		a.emitError("stt", event.Error)
+		return nil
+	}
+
+	isTranscript := event.Type == stt.SpeechEventInterim ||
+		event.Type == stt.SpeechEventPreflightTranscript ||
+		event.Type == stt.SpeechEventFinal
+	if isTranscript {
+		if event.Text != "" {
+			transcript := UserTranscriptEvent{
+				Text:      event.Text,
+				IsFinal:   event.Type == stt.SpeechEventFinal,
+				Language:  event.Language,
+				Timestamp: time.Now(),
+			}
+			if len(event.Alternatives) > 0 {
+				transcript.Confidence = event.Alternatives[0].Confidence
+				transcript.SpeakerID = event.Alternatives[0].SpeakerID
+			}
+			a.events.emit(transcript)
+		}
+		if err := a.observeTranscript(ctx, event.Text); err != nil {
+			return err
+		}
+	}
+
 	if event.Type == stt.SpeechEventFinal && a.GetState() == StateThinking {
+		if a.deliverConfirmation(event.Text) {
+			// The transcript answers a tool confirmation within the current turn
+			return nil
+		}
+
 		// Add user message to conversation history
-		a.conversationMu.Lock()
-		a.conversation = append(a.conversation, llm.Message{
+		a.chatCtx.Append(llm.Message{
 			Role:    llm.RoleUser,
 			Content: event.Text,
 		})
-		a.conversationMu.Unlock()
 
-		// Process the final transcript with LLM
-		return a.processLLMResponse(ctx, event.Text)
+		// Process the final transcript with LLM in its own cancellable turn so
+		// that the main loop stays responsive to interruptions
+		thinkCtx := a.beginThinking(ctx)
+		go func() {
+			if err := a.processLLMResponse(thinkCtx); err != nil {
+				if thinkCtx.Err() != nil {
+					log.Printf("⏹️ LLM response cancelled: %v", err)
+					return
+				}
+				log.Printf("❌ LLM response failed: %v", err)
+				a.emitError("llm", err)
+				a.setState(StateIdle)
+			}
+		}()
 	}
 	return nil
 }
 
+// beginThinking creates the cancellable context for a new LLM turn,
+// cancelling any turn that is still in flight.
+func (a *Agent) beginThinking(ctx context.Context) context.Context {
+	thinkCtx, cancel := context.WithCancel(ctx)
+
+	a.turnMu.Lock()
+	previous := a.thinkCancel
+	a.thinkCancel = cancel
+	a.turnMu.Unlock()
+
+	if previous != nil {
+		previous()
+	}
+	return thinkCtx
+}
+
+// cancelTurn stops in-flight LLM generation and any speech that is playing.
+// A turn waiting for a tool confirmation keeps running, since the user talking
+// over the confirmation prompt is likely answering it.
+func (a *Agent) cancelTurn() {
+	a.turnMu.Lock()
+	defer a.turnMu.Unlock()
+
+	if a.speech != nil {
+		a.speech.interrupt()
+	}
+	if a.awaitingConfirmation() {
+		return
+	}
+	if a.thinkCancel != nil {
+		a.thinkCancel()
+		a.thinkCancel = nil
+	}
+}
+
 // startListening begins STT processing for the current audio stream.
 func (a *Agent) startListening(ctx context.Context) error {
 	a.streamMu.Lock()
@@ -494,9 +799,9 @@ func (a *Agent) startListening(ctx context.Context) error {
 
 	// Create new STT stream
 	stream, err := a.stt.NewStream(ctx, stt.StreamConfig{
-		SampleRate:  48000,
-		NumChannels: 1,
-		Lang:        "en-US",
+		SampleRate:  a.sttSampleRate,
+		NumChannels: a.audio.NumChannels,
+		Lang:        a.audio.Language,
 		MaxRetry:    3,
 	})
 	if err != nil {
@@ -515,18 +820,56 @@ func (a *Agent) startListening(ctx context.Context) error {
 	a.feederDone = feederDone
 	a.feederCancelMu.Unlock()
 
+	// Resample microphone audio the STT does not support
+	var converter *rtc.AudioConverter
+	if a.sttSampleRate != a.audio.SampleRate {
+		converter, err = rtc.NewAudioConverter(a.sttSampleRate, a.audio.NumChannels)
+		if err != nil {
+			stream.CloseSend()
+			return fmt.Errorf("failed to convert audio for STT: %w", err)
+		}
+	}
+	var sent time.Duration // audio pushed to the stream, for usage metrics
+	push := func(frames ...rtc.AudioFrame) error {
+		for _, frame := range frames {
+			if err := stream.Push(frame); err != nil {
+				return err
+			}
+			sent += frame.Duration()
+		}
+		return nil
+	}
+
 	go func() {
 		defer close(feederDone) // Signal completion
 		defer feederCancel()    // Ensures cleanup if feeder exits early
+		defer func() {
+			if sent > 0 {
+				a.recordUsage(metrics.Usage{Kind: metrics.KindSTT, AudioDuration: sent})
+			}
+		}()
 		for {
 			select {
 			case <-feederCtx.Done():
+				// Send the audio still buffered in the converter before CloseSend
+				if converter != nil {
+					push(converter.Flush()...)
+				}
 				return
 			case frame, ok := <-a.micIn:
 				if !ok {
 					return
 				}
-				if err := stream.Push(frame); err != nil {
+				frames := []rtc.AudioFrame{frame}
+				if converter != nil {
+					converted, err := converter.Push(frame)
+					if err != nil {
+						log.Printf("⚠️ Dropping microphone frame: %v", err)
+						continue
+					}
+					frames = converted
+				}
+				if err := push(frames...); err != nil {
 					// STT push failed, likely due to stream closure or error
 					// Exit the feeder goroutine gracefully
 					return
@@ -538,8 +881,27 @@ func (a *Agent) startListening(ctx context.Context) error {
 	return nil
 }
 
+// continueListening begins STT processing unless a stream is already open,
+// such as one started to count the words of an interruption. The open stream
+// is kept so that its partial transcript carries over into the user's turn.
+func (a *Agent) continueListening(ctx context.Context) error {
+	a.streamMu.Lock()
+	open := a.sttStream != nil
+	a.streamMu.Unlock()
+	if open {
+		return nil
+	}
+	return a.startListening(ctx)
+}
+
 // startThinking transitions to thinking state and closes STT stream.
 func (a *Agent) startThinking(ctx context.Context) error {
+	return a.stopListening(ctx)
+}
+
+// stopListening stops feeding microphone audio to STT and closes the stream so
+// that it flushes its final transcript.
+func (a *Agent) stopListening(ctx context.Context) error {
 	// Stop the feeder first to avoid race with CloseSend
 	var feederDone chan struct{}
 	a.feederCancelMu.Lock()
@@ -567,7 +929,9 @@ func (a *Agent) startThinking(ctx context.Context) error {
 	defer a.streamMu.Unlock()
 
 	if a.sttStream != nil {
-		if err := a.sttStream.CloseSend(); err != nil {
+		stream := a.sttStream
+		a.sttStream = nil
+		if err := stream.CloseSend(); err != nil {
 			return fmt.Errorf("failed to close STT stream: %w", err)
 		}
 	}
@@ -575,155 +939,808 @@ func (a *Agent) startThinking(ctx context.Context) error {
 	return nil
 }
 
-// processLLMResponse handles LLM processing with tool calling and TTS synthesis.
-func (a *Agent) processLLMResponse(ctx context.Context, transcript string) error {
-	// Initialize conversation with user message
-	messages := []llm.Message{
-		{Role: llm.RoleUser, Content: transcript},
-	}
+// maxToolCalls bounds the tool calling loop to prevent infinite loops.
+const maxToolCalls = 10
 
-	// Convert tools to function definitions for LLM
-	var functions []llm.FunctionDefinition
-	for _, tool := range a.tools {
-		functions = append(functions, llm.FunctionDefinition{
-			Name:        tool.Name,
-			Description: tool.Description,
-			Parameters:  tool.Schema,
-		})
+// processLLMResponse generates a reply to the conversation in the chat context,
+// handling tool calls and TTS synthesis.
+func (a *Agent) processLLMResponse(ctx context.Context) error {
+	return a.generateLLMReply(ctx, "")
+}
+
+// generateLLMReply generates a reply to the conversation, adding instructions
+// for this reply only when non-empty. Providers that support streaming are
+// driven through processLLMStream so that speech can start as soon as the first
+// sentence is available.
+func (a *Agent) generateLLMReply(ctx context.Context, instructions string) error {
+	if isStreaming(a.currentLLM()) {
+		return a.processLLMStream(ctx, instructions)
 	}
 
-	// Tool calling loop with max depth to prevent infinite loops
-	const maxToolCalls = 10
+	// Tool calling loop with max depth to prevent infinite loops. The LLM and
+	// tools are looked up on every iteration since a tool may hand off to a new task.
 	for i := 0; i < maxToolCalls; i++ {
-		response, err := a.llm.Chat(ctx, llm.ChatRequest{
-			Messages:  messages,
-			Functions: functions,
+		response, err := a.currentLLM().Chat(ctx, llm.ChatRequest{
+			Messages:  a.llmMessages(instructions),
+			Functions: a.functionDefinitions(),
 		})
 		if err != nil {
 			return fmt.Errorf("LLM chat failed: %w", err)
 		}
+		a.recordLLMUsage(response.TokensUsed, response.PromptTokens, response.CompletionTokens)
 
 		// If no function call, we're done - start speaking
-		if response.FunctionCall == nil {
-			// Add assistant message to conversation history
-			a.conversationMu.Lock()
-			a.conversation = append(a.conversation, llm.Message{
-				Role:    llm.RoleAssistant,
-				Content: response.Message.Content,
-			})
-			a.conversationMu.Unlock()
-
-			a.setState(StateSpeaking)
+		toolCalls := requestedToolCalls(response.ToolCalls, response.FunctionCall)
+		if len(toolCalls) == 0 {
 			return a.startSpeaking(ctx, response.Message.Content)
 		}
 
-		// Handle function call
-		log.Printf("🔧 LLM requested function call: %s", response.FunctionCall.Name)
+		a.handleToolCalls(ctx, response.Message.Content, toolCalls)
+	}
 
-		// Add assistant message with function call to history
-		messages = append(messages, llm.Message{
-			Role:    llm.RoleAssistant,
-			Content: response.Message.Content,
-		})
+	// If we hit max tool calls, continue with final response
+	log.Printf("⚠️ Maximum tool calls (%d) reached, proceeding with final response", maxToolCalls)
+	response, err := a.currentLLM().Chat(ctx, llm.ChatRequest{
+		Messages: a.llmMessages(instructions),
+	})
+	if err != nil {
+		return fmt.Errorf("final LLM chat failed: %w", err)
+	}
+	a.recordLLMUsage(response.TokensUsed, response.PromptTokens, response.CompletionTokens)
+
+	return a.startSpeaking(ctx, response.Message.Content)
+}
+
+// processLLMStream streams the LLM response, splits it into sentences and feeds
+// each completed sentence to TTS while the rest of the response is generated.
+// Function calls requested at the end of a stream are executed and the result
+// is sent back to the LLM, exactly as in the non-streaming path.
+func (a *Agent) processLLMStream(ctx context.Context, instructions string) error {
+	tokenizer := tokenize.NewSentenceTokenizer()
+	sentences := make(chan string, 16)
+	var speech *speechHandle
+	var spoken []string
+	closed := false
+	defer func() {
+		if !closed {
+			close(sentences)
+		}
+	}()
 
-		// Execute the function
-		toolResult, err := a.executeTool(ctx, response.FunctionCall)
+	// emit hands a sentence to the speech pipeline, starting it on first use
+	emit := func(sentence string) error {
+		if speech == nil {
+			h, err := a.beginSpeech(ctx, SayOptions{})
+			if err != nil {
+				return err
+			}
+			speech = h
+			a.speakSentences(speech, sentences)
+		}
+		select {
+		case sentences <- sentence:
+			spoken = append(spoken, sentence)
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for i := 0; i <= maxToolCalls; i++ {
+		req := llm.ChatRequest{Messages: a.llmMessages(instructions)}
+		if i < maxToolCalls {
+			req.Functions = a.functionDefinitions()
+		} else {
+			log.Printf("⚠️ Maximum tool calls (%d) reached, proceeding with final response", maxToolCalls)
+		}
+
+		// LLMs that cannot stream, which a handoff may switch to mid-turn,
+		// deliver their whole response as a single chunk
+		chunks, err := llm.ChatStream(ctx, a.currentLLM(), req)
 		if err != nil {
-			log.Printf("❌ Tool execution failed: %v", err)
-			toolResult = fmt.Sprintf("Error: %s", err.Error())
+			return fmt.Errorf("LLM chat stream failed: %w", err)
 		}
 
-		// Add function result to conversation history
-		messages = append(messages, llm.Message{
-			Role:    llm.RoleFunction,
-			Content: toolResult,
-			Name:    response.FunctionCall.Name,
+		var content strings.Builder
+		var toolCalls []llm.ToolCall
+		var tokens, promptTokens, completionTokens int
+		for chunk := range chunks {
+			if chunk.Error != nil {
+				return fmt.Errorf("LLM chat stream failed: %w", chunk.Error)
+			}
+			if calls := requestedToolCalls(chunk.ToolCalls, chunk.FunctionCall); len(calls) > 0 {
+				toolCalls = calls
+			}
+			tokens += chunk.TokensUsed
+			promptTokens += chunk.PromptTokens
+			completionTokens += chunk.CompletionTokens
+			content.WriteString(chunk.Delta)
+			for _, sentence := range tokenizer.Push(chunk.Delta) {
+				if err := emit(sentence); err != nil {
+					return err
+				}
+			}
+		}
+		a.recordLLMUsage(tokens, promptTokens, completionTokens)
+		if len(toolCalls) == 0 {
+			break
+		}
+
+		// The text so far is spoken before the tools run and stored with the
+		// calls, so the utterance only records what is said after them
+		if rest := tokenizer.Flush(); rest != "" {
+			if err := emit(rest); err != nil {
+				return err
+			}
+		}
+		if speech != nil {
+			speech.markRecorded(strings.Join(spoken, " "))
+		}
+		a.handleToolCalls(ctx, content.String(), toolCalls)
+	}
+
+	if rest := tokenizer.Flush(); rest != "" {
+		if err := emit(rest); err != nil {
+			return err
+		}
+	}
+	close(sentences)
+	closed = true
+
+	if speech == nil {
+		// Nothing to say, go back to idle
+		a.setState(StateIdle)
+	}
+	return nil
+}
+
+// llmMessages returns the chat context messages for an LLM request, followed by
+// instructions for this reply only when non-empty.
+func (a *Agent) llmMessages(instructions string) []llm.Message {
+	messages := a.chatCtx.Messages()
+	if instructions != "" {
+		messages = append(messages, llm.Message{Role: llm.RoleSystem, Content: instructions})
+	}
+	return messages
+}
+
+// isStreaming reports whether the LLM can stream its response.
+func isStreaming(model llm.LLM) bool {
+	_, ok := model.(llm.StreamingLLM)
+	return ok && model.Capabilities().SupportsStreaming
+}
+
+// functionDefinitions converts the agent's tools to LLM function definitions.
+func (a *Agent) functionDefinitions() []llm.FunctionDefinition {
+	a.taskMu.RLock()
+	defer a.taskMu.RUnlock()
+
+	var functions []llm.FunctionDefinition
+	for _, tool := range a.tools {
+		functions = append(functions, llm.FunctionDefinition{
+			Name:        tool.Name,
+			Description: tool.Description,
+			Parameters:  tool.Schema,
 		})
+	}
+	return functions
+}
+
+// defaultToolTimeout bounds tool execution for tools without their own Timeout.
+const defaultToolTimeout = 30 * time.Second
+
+// requestedToolCalls returns the tool calls from an LLM response, falling back
+// to FunctionCall for providers that only report a single call.
+func requestedToolCalls(toolCalls []llm.ToolCall, functionCall *llm.FunctionCall) []llm.ToolCall {
+	if len(toolCalls) > 0 {
+		return toolCalls
+	}
+	if functionCall != nil {
+		return []llm.ToolCall{{Name: functionCall.Name, Arguments: functionCall.Arguments}}
+	}
+	return nil
+}
 
-		log.Printf("✅ Tool %s executed, result: %s", response.FunctionCall.Name, toolResult)
+// handleToolCalls executes the tool calls requested by the LLM concurrently and
+// records the calls and their results in the chat context so the next request
+// includes them. Results are recorded in the order the LLM requested the calls.
+func (a *Agent) handleToolCalls(ctx context.Context, content string, toolCalls []llm.ToolCall) {
+	// Results reference their call by ID, so calls without one are assigned an ID
+	calls := make([]llm.ToolCall, len(toolCalls))
+	for i, call := range toolCalls {
+		if call.ID == "" {
+			call.ID = fmt.Sprintf("call_%d", a.toolCallSeq.Add(1))
+		}
+		calls[i] = call
 	}
 
-	// If we hit max tool calls, continue with final response
-	log.Printf("⚠️ Maximum tool calls (%d) reached, proceeding with final response", maxToolCalls)
-	response, err := a.llm.Chat(ctx, llm.ChatRequest{
-		Messages: messages,
+	// Add assistant message with the tool calls to history
+	a.chatCtx.Append(llm.Message{
+		Role:      llm.RoleAssistant,
+		Content:   content,
+		ToolCalls: calls,
 	})
-	if err != nil {
-		return fmt.Errorf("final LLM chat failed: %w", err)
+
+	task := a.Task()
+	results := make([]string, len(calls))
+	filler := &toolFiller{}
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = a.runToolCall(ctx, call, filler)
+		}()
+	}
+	wg.Wait()
+	filler.wait(ctx)
+
+	// A handoff that summarized or reset the history has already dropped the
+	// calls, so their results are only recorded when the history was kept
+	if next := a.Task(); next != task && next.ChatContext != ChatContextKeep {
+		log.Printf("✅ Tool calls handed off to %q", next.Name)
+		return
 	}
 
-	// Add final assistant message to conversation history
-	a.conversationMu.Lock()
-	a.conversation = append(a.conversation, llm.Message{
-		Role:    llm.RoleAssistant,
-		Content: response.Message.Content,
+	// Add tool results to conversation history
+	for i, call := range calls {
+		a.chatCtx.Append(llm.Message{
+			Role:       llm.RoleTool,
+			Content:    results[i],
+			Name:       call.Name,
+			ToolCallID: call.ID,
+		})
+	}
+}
+
+// runToolCall executes a single tool call, records it and returns the result to
+// send back to the LLM. Failures are reported to the LLM as the result. Tools
+// that require confirmation only run once the user agreed, and slow tools
+// speak their filler while running.
+func (a *Agent) runToolCall(ctx context.Context, call llm.ToolCall, filler *toolFiller) string {
+	log.Printf("🔧 LLM requested tool call: %s (%s)", call.Name, call.ID)
+
+	started := time.Now()
+	a.events.emit(ToolCallStartedEvent{
+		CallID:    call.ID,
+		Name:      call.Name,
+		Arguments: call.Arguments,
+		Timestamp: started,
+	})
+	toolResult, err := a.confirmAndExecuteTool(ctx, call, filler)
+	record := llm.ToolCallRecord{
+		ID:        call.ID,
+		Name:      call.Name,
+		Arguments: call.Arguments,
+		Started:   started,
+		Duration:  time.Since(started),
+	}
+	if err != nil {
+		log.Printf("❌ Tool execution failed: %v", err)
+		record.Error = err.Error()
+		toolResult = fmt.Sprintf("Error: %s", err.Error())
+	}
+	record.Result = toolResult
+	a.chatCtx.AddToolCall(record)
+	a.events.emit(ToolCallFinishedEvent{
+		CallID:    call.ID,
+		Name:      call.Name,
+		Arguments: call.Arguments,
+		Result:    toolResult,
+		Error:     err,
+		Duration:  record.Duration,
+		Timestamp: time.Now(),
 	})
-	a.conversationMu.Unlock()
 
-	a.setState(StateSpeaking)
-	return a.startSpeaking(ctx, response.Message.Content)
+	log.Printf("✅ Tool %s executed, result: %s", call.Name, toolResult)
+	return toolResult
 }
 
-// executeTool executes a function call and returns the result
-func (a *Agent) executeTool(ctx context.Context, functionCall *llm.FunctionCall) (string, error) {
-	tool, exists := a.tools[functionCall.Name]
+// executeTool executes a tool call and returns the result. The handler is given
+// the tool's timeout; a handler that ignores its context is abandoned when the
+// timeout expires.
+func (a *Agent) executeTool(ctx context.Context, call llm.ToolCall) (string, error) {
+	tool, exists := a.currentTool(call.Name)
 	if !exists {
-		return "", fmt.Errorf("unknown function: %s", functionCall.Name)
+		return "", fmt.Errorf("unknown function: %s", call.Name)
 	}
 
 	// Validate that arguments is valid JSON
 	var args map[string]any
-	if err := json.Unmarshal([]byte(functionCall.Arguments), &args); err != nil {
+	if err := json.Unmarshal([]byte(call.Arguments), &args); err != nil {
 		return "", fmt.Errorf("invalid function arguments JSON: %w", err)
 	}
 
-	// Execute the tool handler
-	return tool.Handler(ctx, functionCall.Arguments)
+	timeout := tool.Timeout
+	if timeout <= 0 {
+		timeout = defaultToolTimeout
+	}
+	// Give the handler access to the agent for handoffs
+	ctx, cancel := context.WithTimeout(withAgent(ctx, a), timeout)
+	defer cancel()
+
+	type toolResult struct {
+		result string
+		err    error
+	}
+	done := make(chan toolResult, 1)
+	go func() {
+		result, err := tool.Handler(ctx, call.Arguments)
+		done <- toolResult{result, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.result, r.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("tool %s timed out after %v", call.Name, timeout)
+		}
+		return "", ctx.Err()
+	}
 }
 
 // startSpeaking begins TTS synthesis and audio playback.
 func (a *Agent) startSpeaking(ctx context.Context, text string) error {
-	// Record first word latency if this is the first response (thread-safe)
-	a.firstWordTimeOnce.Do(func() {
-		a.firstWordTime = time.Now()
-		latency := a.firstWordTime.Sub(a.sessionStart)
-		a.metrics.FirstWordLatency.Set(float64(latency.Milliseconds()))
-	})
-
-	// Synthesize speech
-	audioFrames, err := a.tts.Synthesize(ctx, tts.SynthesizeRequest{
-		Text:     text,
-		Voice:    "default",
-		Language: "en-US",
-	})
+	speech, err := a.beginSpeech(ctx, SayOptions{})
 	if err != nil {
+		return err
+	}
+	return a.speakText(speech, text)
+}
+
+// speakText synthesizes text for an utterance started with beginSpeech and
+// plays it back in the background. It returns an error if synthesis failed
+// to start and the failure can neither be retried nor covered by the fallback
+// message.
+func (a *Agent) speakText(speech *speechHandle, text string) error {
+	a.recordFirstWord()
+	speech.addSegment(text)
+
+	audio, err := a.synthesize(speech.ctx, text)
+	if err != nil && a.ttsFallback == "" && !a.canRetryTTS(err, 1) {
+		a.emitError("tts", err)
+		speech.interrupt()
+		a.finishSpeech(speech)
 		return fmt.Errorf("TTS synthesis failed: %w", err)
 	}
 
 	// Stream audio frames to output
 	go func() {
-		defer func() {
-			// Return to idle state when speaking is done
-			a.setState(StateIdle)
-		}()
+		defer a.finishSpeech(speech)
+		a.speakSegment(speech, text, audio, err)
+	}()
+
+	return nil
+}
 
-		for frame := range audioFrames {
-			// Mix with background audio if enabled
-			if a.backgroundAudio != nil && a.backgroundAudio.IsEnabled() {
-				frame = a.backgroundAudio.MixFrames(frame)
+// speakSentences synthesizes sentences as they arrive and plays them back in order.
+// Playback of a sentence starts as soon as its synthesis begins, so first-audio
+// latency is bounded by the first sentence rather than the full response.
+// The utterance finishes once the channel is closed and drained, or a sentence
+// fails to synthesize.
+func (a *Agent) speakSentences(speech *speechHandle, sentences <-chan string) {
+	go func() {
+		defer a.finishSpeech(speech)
+
+		synthesizer, voiceName := a.currentTTS()
+		if streaming, ok := synthesizer.(tts.StreamingTTS); ok && synthesizer.Capabilities().Streaming {
+			a.streamSentences(speech, streaming, voiceName, sentences)
+			return
+		}
+
+		for sentence := range sentences {
+			a.recordFirstWord()
+			speech.addSegment(sentence)
+
+			audio, err := a.synthesize(speech.ctx, sentence)
+			if !a.speakSegment(speech, sentence, audio, err) {
+				// Drain remaining sentences so the producer never blocks
+				for range sentences {
+				}
+				return
 			}
+		}
+	}()
+}
 
+// streamSentences synthesizes sentences through a single streaming TTS
+// session, so the provider sees the reply as one continuous text.
+func (a *Agent) streamSentences(speech *speechHandle, synthesizer tts.StreamingTTS, voiceName string, sentences <-chan string) {
+	req := a.synthesizeRequest("", voiceName)
+	req.WordTimings = true
+	stream, err := synthesizer.SynthesizeStream(speech.ctx, req)
+	if err != nil {
+		log.Printf("❌ Failed to open TTS stream: %v", err)
+		a.failSpeech(speech, err)
+		for range sentences {
+		}
+		return
+	}
+
+	go func() {
+		defer stream.Close()
+		for sentence := range sentences {
+			a.recordFirstWord()
+			speech.addSegment(sentence)
+			if err := stream.PushText(sentence + " "); err != nil {
+				// The error is reported once the stream's audio has been played
+				break
+			}
+			// Each sentence is complete, synthesize it without waiting for the next
+			stream.Flush()
+		}
+		// Drain remaining sentences so the producer never blocks
+		for range sentences {
+		}
+	}()
+
+	// Audio is not attributed to sentences, so the whole reply is truncated
+	// using the timings the stream reports for its text
+	timings := collectWords(stream.Words())
+	frames := a.meterTTS(speech.ctx, stream.Frames(), func() int { return utf8.RuneCountInString(speech.text()) })
+	played, complete := a.playFrames(speech, frames, a.captioner(timings))
+	if complete {
+		err = stream.Result().Err
+	}
+	speech.markPlayed(speech.text(), timings.snapshot(), played, complete && err == nil)
+	if err != nil {
+		a.failSpeech(speech, err)
+	}
+}
+
+// segmentAudio is the synthesis of one segment of an utterance.
+type segmentAudio struct {
+	frames  <-chan rtc.AudioFrame
+	timings *wordTimings
+	result  func() tts.SynthesisResult // nil if the TTS does not report a result
+}
+
+// err returns the error that ended synthesis. It must only be called once
+// all frames were received.
+func (s *segmentAudio) err() error {
+	if s.result == nil {
+		return nil
+	}
+	return s.result().Err
+}
+
+// synthesize starts synthesizing a segment with the current TTS. TTS providers
+// that synthesize through sessions report the word timings and how synthesis
+// ended, for other providers the timings are estimated from the speaking rate.
+func (a *Agent) synthesize(ctx context.Context, text string) (*segmentAudio, error) {
+	synthesizer, voiceName := a.currentTTS()
+	req := a.synthesizeRequest(text, voiceName)
+	characters := func() int { return utf8.RuneCountInString(req.Text) }
+
+	if streaming, ok := synthesizer.(tts.StreamingTTS); ok {
+		req.WordTimings = true
+		stream, err := streaming.SynthesizeStream(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		defer stream.Close()
+		if err := stream.PushText(req.Text); err != nil {
+			return nil, err
+		}
+		return &segmentAudio{frames: a.meterTTS(ctx, stream.Frames(), characters), timings: collectWords(stream.Words()), result: stream.Result}, nil
+	}
+
+	audioFrames, err := synthesizer.Synthesize(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	timings := &wordTimings{}
+	timings.words = tts.EstimateWordTimings(req.Text, a.speakingRate())
+	return &segmentAudio{frames: a.meterTTS(ctx, audioFrames, characters), timings: timings}, nil
+}
+
+// meterTTS forwards synthesized frames and records the TTS usage once all of
+// them were produced. characters is only called then, so that it can count
+// text pushed to a stream while it plays.
+func (a *Agent) meterTTS(ctx context.Context, frames <-chan rtc.AudioFrame, characters func() int) <-chan rtc.AudioFrame {
+	metered := make(chan rtc.AudioFrame, 10)
+	go func() {
+		defer close(metered)
+		var produced time.Duration
+		for frame := range frames {
+			produced += frame.Duration()
+			// Frames produced after cancellation are discarded
+			select {
+			case metered <- frame:
+			case <-ctx.Done():
+			}
+		}
+		a.recordUsage(metrics.Usage{Kind: metrics.KindTTS, Characters: characters(), AudioDuration: produced})
+	}()
+	return metered
+}
+
+// speakSegment plays a segment of an utterance whose synthesis was started
+// with synthesize, which returned audio and err. Recoverable failures are
+// retried as long as none of the segment's audio was played. Other failures
+// stop the utterance and speak the fallback message. It returns false if the
+// utterance was stopped.
+func (a *Agent) speakSegment(speech *speechHandle, text string, audio *segmentAudio, err error) bool {
+	for attempt := 1; ; attempt++ {
+		var played time.Duration
+		if err == nil {
+			var complete bool
+			played, complete = a.playFrames(speech, audio.frames, a.captioner(audio.timings))
+			if complete {
+				err = audio.err()
+			}
+			speech.markPlayed(text, audio.timings.snapshot(), played, complete && err == nil)
+			if !complete || err == nil {
+				return complete
+			}
+		}
+		if speech.ctx.Err() != nil {
+			return false
+		}
+
+		if played > 0 || !a.canRetryTTS(err, attempt) {
+			a.failSpeech(speech, err)
+			return false
+		}
+		delay := a.ttsRetry.Backoff(attempt)
+		log.Printf("⚠️ TTS synthesis failed, retrying in %v (attempt %d/%d): %v", delay, attempt, a.ttsRetry.MaxRetries, err)
+		select {
+		case <-time.After(delay):
+		case <-speech.ctx.Done():
+			return false
+		case <-a.shutdown:
+			return false
+		}
+		audio, err = a.synthesize(speech.ctx, text)
+	}
+}
+
+// canRetryTTS reports whether a TTS failure may be retried for the given attempt.
+func (a *Agent) canRetryTTS(err error, attempt int) bool {
+	return ai.IsRecoverable(err) && attempt <= a.ttsRetry.MaxRetries
+}
+
+// failSpeech reports a synthesis failure that stopped the utterance and
+// speaks the fallback message in its place.
+func (a *Agent) failSpeech(speech *speechHandle, err error) {
+	log.Printf("❌ TTS synthesis failed: %v", err)
+	a.emitError("tts", err)
+	speech.fail(err)
+
+	if a.ttsFallback == "" {
+		return
+	}
+	// The fallback is not part of the reply, so it is kept out of the chat context
+	audio, err := a.synthesize(speech.ctx, a.ttsFallback)
+	if err != nil {
+		log.Printf("❌ TTS fallback message failed: %v", err)
+		return
+	}
+	a.playFrames(speech, audio.frames, nil)
+}
+
+// speakingRate returns the estimated characters per second of the TTS voice.
+func (a *Agent) speakingRate() float64 {
+	if a.audio.Speed > 0 {
+		return tts.DefaultCharsPerSecond * float64(a.audio.Speed)
+	}
+	return tts.DefaultCharsPerSecond
+}
+
+// captioner returns a playback callback that emits an AgentTranscriptEvent for
+// each word of a segment once its audio starts playing.
+func (a *Agent) captioner(timings *wordTimings) func(played time.Duration) {
+	next := 0
+	return func(played time.Duration) {
+		words := timings.snapshot()
+		for ; next < len(words) && words[next].Start < played; next++ {
+			a.events.emit(AgentTranscriptEvent{Word: words[next].Word, Timestamp: time.Now()})
+		}
+	}
+}
+
+// speakInterim speaks text in the middle of a turn, such as a tool filler or a
+// confirmation prompt. The text is kept out of the chat context and the agent
+// moves to the after state once it has been spoken.
+func (a *Agent) speakInterim(ctx context.Context, text string, after AgentState) (*speechHandle, error) {
+	speech, err := a.beginSpeech(ctx, SayOptions{SkipChatContext: true})
+	if err != nil {
+		return nil, err
+	}
+	speech.after = after
+	if err := a.speakText(speech, text); err != nil {
+		// The turn carries on without the interim speech
+		a.setState(StateThinking)
+		return nil, err
+	}
+	return speech, nil
+}
+
+// beginSpeech transitions to the speaking state and registers a new utterance.
+// It fails if ctx was cancelled, which happens when the turn was interrupted
+// before the LLM produced anything to say.
+func (a *Agent) beginSpeech(ctx context.Context, opts SayOptions) (*speechHandle, error) {
+	a.turnMu.Lock()
+	defer a.turnMu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	speech := newSpeechHandle(ctx, opts)
+	a.speech = speech
+	if observe, ok := ctx.Value(speechObserverKey{}).(func(*speechHandle)); ok {
+		observe(speech)
+	}
+	a.setState(StateSpeaking)
+	if a.interruption.Disabled && a.audioGate != nil {
+		a.audioGate.SetTTSPlaying(true)
+	}
+	a.events.emit(SpeechStartedEvent{Timestamp: time.Now()})
+	return speech, nil
+}
+
+// finishSpeech records the utterance in the conversation history and returns to
+// idle unless the utterance was interrupted. Interrupted and failed utterances
+// are stored truncated to the text that was actually played before the cut.
+func (a *Agent) finishSpeech(speech *speechHandle) {
+	defer close(speech.done)
+	defer speech.cancel()
+
+	content := speech.text()
+	interrupted := speech.isInterrupted()
+	if interrupted {
+		content = speech.playedText()
+		log.Printf("⏹️ Assistant speech interrupted after: %q", content)
+		a.events.emit(SpeechInterruptedEvent{Text: speech.text(), PlayedText: content, Timestamp: time.Now()})
+	} else {
+		if speech.failure() != nil {
+			// Only the text that was heard before synthesis failed is kept
+			content = speech.playedText()
+		}
+		a.events.emit(SpeechFinishedEvent{Text: content, Timestamp: time.Now()})
+	}
+
+	// Text stored with tool calls is not recorded again
+	if recorded := speech.unrecorded(content); recorded != "" && !speech.opts.SkipChatContext {
+		a.chatCtx.Append(llm.Message{
+			Role:    llm.RoleAssistant,
+			Content: recorded,
+		})
+	}
+
+	a.turnMu.Lock()
+	current := a.speech == speech
+	if current {
+		a.speech = nil
+		if a.audioGate != nil {
+			a.audioGate.SetTTSPlaying(false)
+		}
+	}
+	a.turnMu.Unlock()
+
+	if current && !interrupted {
+		// Return to idle state when speaking is done, or to thinking after
+		// interim speech spoken while a turn is still in progress
+		a.setState(speech.after)
+	}
+}
+
+// playFrames forwards synthesized audio to the output, mixing in background audio
+// when enabled. Playback holds while the utterance is paused. It returns the
+// duration of audio that reached the output and false if playback was aborted
+// by cancellation or shutdown. onPlayed, if set, is called with the total
+// duration played after each frame.
+func (a *Agent) playFrames(speech *speechHandle, audioFrames <-chan rtc.AudioFrame, onPlayed func(time.Duration)) (time.Duration, bool) {
+	// TTS audio is converted to the output format in 10 ms frames. The format
+	// was validated in New, so the converter cannot fail to be created.
+	converter, _ := rtc.NewAudioConverter(a.audio.OutputSampleRate, a.audio.OutputChannels)
+
+	var played time.Duration
+	for frame := range audioFrames {
+		frames, err := converter.Push(frame)
+		if err != nil {
+			log.Printf("⚠️ Dropping TTS frame: %v", err)
+			continue
+		}
+		if !a.writeFrames(speech, frames, &played, onPlayed) {
+			return played, false
+		}
+	}
+	if !a.writeFrames(speech, converter.Flush(), &played, onPlayed) {
+		return played, false
+	}
+	return played, speech.ctx.Err() == nil
+}
+
+// writeFrames writes frames to the output, adding their duration to played. It
+// returns false if the utterance was stopped.
+func (a *Agent) writeFrames(speech *speechHandle, frames []rtc.AudioFrame, played *time.Duration, onPlayed func(time.Duration)) bool {
+	ctx := speech.ctx
+	for _, frame := range frames {
+		if !speech.waitResumed() || ctx.Err() != nil {
+			return false
+		}
+
+		// Mix with background audio if enabled
+		if a.backgroundAudio != nil && a.backgroundAudio.IsEnabled() {
+			frame = a.backgroundAudio.MixFrames(frame)
+		}
+
+		select {
+		case a.ttsOut <- frame:
+			*played += frame.Duration()
+			if onPlayed != nil {
+				onPlayed(*played)
+			}
+		case <-ctx.Done():
+			return false
+		case <-a.shutdown:
+			return false
+		}
+	}
+	return true
+}
+
+// gateAudio returns a channel that forwards microphone frames unless the audio
+// gate says they should be discarded.
+func (a *Agent) gateAudio(ctx context.Context, in <-chan rtc.AudioFrame) <-chan rtc.AudioFrame {
+	out := make(chan rtc.AudioFrame, cap(in))
+	go func() {
+		defer close(out)
+		for {
 			select {
-			case a.ttsOut <- frame:
 			case <-ctx.Done():
 				return
-			case <-a.shutdown:
-				return
+			case frame, ok := <-in:
+				if !ok {
+					return
+				}
+				if a.audioGate.ShouldDiscardAudio() {
+					continue
+				}
+				select {
+				case out <- frame:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
 	}()
+	return out
+}
 
-	return nil
+// recordFirstWord records the first word latency on the first response (thread-safe).
+func (a *Agent) recordFirstWord() {
+	a.firstWordTimeOnce.Do(func() {
+		a.firstWordTime = time.Now()
+		latency := a.firstWordTime.Sub(a.sessionStart)
+		a.metrics.FirstWordLatency.Set(float64(latency.Milliseconds()))
+		a.emitMetrics(map[string]float64{"first_word_latency_ms": float64(latency.Milliseconds())})
+	})
+}
+
+// recordUsage passes the usage of a provider call to the usage collector.
+func (a *Agent) recordUsage(usage metrics.Usage) {
+	if collector := a.usage.Load(); collector != nil {
+		collector.Record(usage)
+	}
+}
+
+// recordLLMUsage records the tokens used by an LLM request.
+func (a *Agent) recordLLMUsage(total, prompt, completion int) {
+	a.recordUsage(metrics.Usage{
+		Kind:             metrics.KindLLM,
+		PromptTokens:     prompt,
+		CompletionTokens: completion,
+		TotalTokens:      total,
+	})
+}
+
+// emitMetrics publishes a MetricsCollectedEvent.
+func (a *Agent) emitMetrics(metrics map[string]float64) {
+	a.events.emit(MetricsCollectedEvent{Metrics: metrics, Timestamp: time.Now()})
 }
 
 // updateSessionDuration updates the session duration metric.
@@ -2,13 +2,19 @@ package agent
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm"
 	"github.com/chriscow/livekit-agents-go/pkg/ai/llm/fake"
 	sttfake "github.com/chriscow/livekit-agents-go/pkg/ai/stt/fake"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/tts"
 	ttsfake "github.com/chriscow/livekit-agents-go/pkg/ai/tts/fake"
 	vadfake "github.com/chriscow/livekit-agents-go/pkg/ai/vad/fake"
 	"github.com/chriscow/livekit-agents-go/pkg/job"
@@ -608,3 +614,638 @@ func TestAgent_SimulateConversation(t *testing.T) {
 
 	t.Logf("Conversation completed with %d state changes and %d TTS frames", len(stateChanges), atomic.LoadInt64(&ttsFrameCount))
 }
+
+// recordingTTS records synthesis requests and emits a single frame per request.
+type recordingTTS struct {
+	mu      sync.Mutex
+	texts   []string
+	lastReq tts.SynthesizeRequest
+}
+
+func (r *recordingTTS) Synthesize(ctx context.Context, req tts.SynthesizeRequest) (<-chan rtc.AudioFrame, error) {
+	r.mu.Lock()
+	r.texts = append(r.texts, req.Text)
+	r.lastReq = req
+	r.mu.Unlock()
+
+	frames := make(chan rtc.AudioFrame, 1)
+	frames <- rtc.AudioFrame{
+		Data:              make([]byte, 960),
+		SampleRate:        48000,
+		SamplesPerChannel: 480,
+		NumChannels:       1,
+	}
+	close(frames)
+	return frames, nil
+}
+
+func (r *recordingTTS) Capabilities() tts.TTSCapabilities {
+	return tts.TTSCapabilities{Streaming: false, SampleRates: []int{48000}}
+}
+
+func (r *recordingTTS) requests() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.texts...)
+}
+
+// newTestAgent creates an agent backed by fakes, letting configure override
+// any of the defaults. Audio written to TTSOut is drained until the test ends.
+func newTestAgent(t *testing.T, configure func(*Config)) *Agent {
+	t.Helper()
+
+	ttsOut := make(chan rtc.AudioFrame, 10)
+	cfg := Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          ttsfake.NewFakeTTS(),
+		LLM:          fake.NewFakeLLM(),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       ttsOut,
+	}
+	if configure != nil {
+		configure(&cfg)
+	}
+
+	agent, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		close(done)
+		agent.Close()
+	})
+	go func() {
+		for {
+			select {
+			case <-ttsOut:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return agent
+}
+
+// countingStreamTTS is a streaming TTS that counts the sessions it opens.
+type countingStreamTTS struct {
+	*tts.StreamAdapter
+	sessions atomic.Int32
+}
+
+func (c *countingStreamTTS) SynthesizeStream(ctx context.Context, req tts.SynthesizeRequest) (tts.SynthesizeStream, error) {
+	c.sessions.Add(1)
+	return c.StreamAdapter.SynthesizeStream(ctx, req)
+}
+
+// TestAgent_StreamingTTS verifies that a streamed reply is pushed to a
+// streaming TTS through one session.
+func TestAgent_StreamingTTS(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	recorder := &recordingTTS{}
+	synthesizer := &countingStreamTTS{StreamAdapter: tts.NewStreamAdapter(recorder)}
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.TTS = synthesizer
+		cfg.LLM = fake.NewFakeStreamingLLM("First sentence here. Second sentence follows.")
+	})
+
+	agent.setState(StateThinking)
+	agent.chatCtx.Append(llm.Message{Role: llm.RoleUser, Content: "hi"})
+	if err := agent.processLLMResponse(ctx); err != nil {
+		t.Fatalf("processLLMResponse failed: %v", err)
+	}
+	waitForState(t, ctx, agent, StateIdle)
+
+	if n := synthesizer.sessions.Load(); n != 1 {
+		t.Errorf("expected one streaming session, got %d", n)
+	}
+	want := []string{"First sentence here.", "Second sentence follows.", "(You said: hi)"}
+	if got := recorder.requests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected sentences %q, got %q", want, got)
+	}
+
+	history := agent.chatCtx.History()
+	if last := history[len(history)-1]; last.Content != "First sentence here. Second sentence follows. (You said: hi)" {
+		t.Errorf("unexpected assistant message in history: %+v", last)
+	}
+}
+
+// TestAgent_StreamingLLM verifies that a streaming LLM response is synthesized
+// sentence by sentence rather than as one block of text.
+func TestAgent_StreamingLLM(t *testing.T) {
+	ttsOut := make(chan rtc.AudioFrame, 10)
+	ttsProvider := &recordingTTS{}
+
+	agent, err := New(Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          ttsProvider,
+		LLM:          fake.NewFakeStreamingLLM("First sentence here. Second sentence follows."),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       ttsOut,
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	go func() {
+		for {
+			select {
+			case <-ttsOut:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	agent.setState(StateThinking)
+	agent.chatCtx.Append(llm.Message{Role: llm.RoleUser, Content: "hi"})
+	if err := agent.processLLMResponse(ctx); err != nil {
+		t.Fatalf("processLLMResponse failed: %v", err)
+	}
+
+	for agent.GetState() != StateIdle {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("agent did not return to idle, state %v", agent.GetState())
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	want := []string{"First sentence here.", "Second sentence follows.", "(You said: hi)"}
+	got := ttsProvider.requests()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d TTS requests, got %d: %q", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("TTS request %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+
+	history := agent.chatCtx.History()
+	last := history[len(history)-1]
+	if last.Role != llm.RoleAssistant || last.Content != "First sentence here. Second sentence follows. (You said: hi)" {
+		t.Errorf("unexpected assistant message in history: %+v", last)
+	}
+}
+
+// TestAgent_InterruptCancelsSpeech verifies that an interruption stops TTS playback
+// immediately and that only the played portion of the reply is kept in history.
+func TestAgent_InterruptCancelsSpeech(t *testing.T) {
+	ttsOut := make(chan rtc.AudioFrame)
+
+	agent, err := New(Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          ttsfake.NewFakeTTS(),
+		LLM:          fake.NewFakeLLM(),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       ttsOut,
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reply := "This is a rather long reply that is going to be cut off by the user."
+	if err := agent.startSpeaking(ctx, reply); err != nil {
+		t.Fatalf("startSpeaking failed: %v", err)
+	}
+	if agent.GetState() != StateSpeaking {
+		t.Fatalf("expected Speaking state, got %v", agent.GetState())
+	}
+
+	// Play roughly one second of audio
+	for i := 0; i < 100; i++ {
+		select {
+		case <-ttsOut:
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for TTS audio")
+		}
+	}
+
+	agent.turnMu.Lock()
+	speech := agent.speech
+	agent.turnMu.Unlock()
+
+	if err := agent.handleInterrupt(ctx); err != nil {
+		t.Fatalf("handleInterrupt failed: %v", err)
+	}
+
+	select {
+	case <-speech.done:
+	case <-time.After(time.Second):
+		t.Fatal("speech did not stop after interruption")
+	}
+
+	select {
+	case <-ttsOut:
+		t.Error("received TTS audio after interruption")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if agent.GetState() != StateListening {
+		t.Errorf("expected Listening state after interruption, got %v", agent.GetState())
+	}
+
+	history := agent.chatCtx.History()
+	if len(history) != 1 {
+		t.Fatalf("expected 1 message in history, got %d", len(history))
+	}
+	played := history[0].Content
+	if played == "" || played == reply || !strings.HasPrefix(reply, played) {
+		t.Errorf("expected a truncated prefix of the reply in history, got %q", played)
+	}
+}
+
+// recordingLLM wraps an LLM and records the messages of every request.
+type recordingLLM struct {
+	llm.LLM
+	mu       sync.Mutex
+	requests [][]llm.Message
+}
+
+func (r *recordingLLM) Chat(ctx context.Context, req llm.ChatRequest) (llm.ChatResponse, error) {
+	r.mu.Lock()
+	r.requests = append(r.requests, req.Messages)
+	r.mu.Unlock()
+	return r.LLM.Chat(ctx, req)
+}
+
+// waitForState polls until the agent reaches the given state.
+func waitForState(t *testing.T, ctx context.Context, agent *Agent, state AgentState) {
+	t.Helper()
+	for agent.GetState() != state {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("agent did not reach %v, state %v", state, agent.GetState())
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+// TestAgent_MultiTurnChatContext verifies that instructions and the full history
+// are sent to the LLM on every turn.
+func TestAgent_MultiTurnChatContext(t *testing.T) {
+	ttsOut := make(chan rtc.AudioFrame, 10)
+	llmProvider := &recordingLLM{LLM: fake.NewFakeLLM("First answer.", "Second answer.")}
+
+	agent, err := New(Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          &recordingTTS{},
+		LLM:          llmProvider,
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       ttsOut,
+		Instructions: "Be brief.",
+		Truncation:   llm.MaxMessages(10),
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	go func() {
+		for {
+			select {
+			case <-ttsOut:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for _, utterance := range []string{"first question", "second question"} {
+		agent.chatCtx.Append(llm.Message{Role: llm.RoleUser, Content: utterance})
+		if err := agent.processLLMResponse(ctx); err != nil {
+			t.Fatalf("processLLMResponse failed: %v", err)
+		}
+		waitForState(t, ctx, agent, StateIdle)
+	}
+
+	llmProvider.mu.Lock()
+	defer llmProvider.mu.Unlock()
+	if len(llmProvider.requests) != 2 {
+		t.Fatalf("expected 2 LLM requests, got %d", len(llmProvider.requests))
+	}
+
+	second := llmProvider.requests[1]
+	wantRoles := []llm.MessageRole{llm.RoleSystem, llm.RoleUser, llm.RoleAssistant, llm.RoleUser}
+	if len(second) != len(wantRoles) {
+		t.Fatalf("expected %d messages in second request, got %d: %+v", len(wantRoles), len(second), second)
+	}
+	for i, role := range wantRoles {
+		if second[i].Role != role {
+			t.Errorf("message %d: expected role %s, got %s", i, role, second[i].Role)
+		}
+	}
+	if second[0].Content != "Be brief." {
+		t.Errorf("expected instructions as system message, got %q", second[0].Content)
+	}
+	if second[1].Content != "first question" || second[3].Content != "second question" {
+		t.Errorf("unexpected history in second request: %+v", second)
+	}
+}
+
+// scriptedStreamLLM streams one scripted reply per request.
+type scriptedStreamLLM struct {
+	*fake.FakeLLM
+	mu      sync.Mutex
+	replies [][]llm.ChatChunk
+}
+
+func (s *scriptedStreamLLM) ChatStream(ctx context.Context, req llm.ChatRequest) (<-chan llm.ChatChunk, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	chunks := make(chan llm.ChatChunk, 10)
+	if len(s.replies) > 0 {
+		for _, chunk := range s.replies[0] {
+			chunks <- chunk
+		}
+		s.replies = s.replies[1:]
+	}
+	close(chunks)
+	return chunks, nil
+}
+
+// TestAgent_StreamedToolRound verifies that text streamed before a tool call
+// is spoken before the tool runs and stored only once, with the call.
+func TestAgent_StreamedToolRound(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	ttsProvider := &recordingTTS{}
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.TTS = ttsProvider
+		cfg.LLM = &scriptedStreamLLM{
+			FakeLLM: fake.NewFakeStreamingLLM(),
+			replies: [][]llm.ChatChunk{
+				{{Delta: "Let me "}, {Delta: "check"}, {ToolCalls: []llm.ToolCall{{ID: "call_1", Name: "lookup", Arguments: "{}"}}}},
+				{{Delta: "It is sunny."}},
+			},
+		}
+		cfg.Tools = []Tool{{
+			Name: "lookup",
+			Handler: func(ctx context.Context, args string) (string, error) {
+				return "sunny", nil
+			},
+		}}
+	})
+
+	agent.chatCtx.Append(llm.Message{Role: llm.RoleUser, Content: "What's the weather?"})
+	agent.setState(StateThinking)
+	if err := agent.processLLMResponse(ctx); err != nil {
+		t.Fatalf("processLLMResponse failed: %v", err)
+	}
+	waitForState(t, ctx, agent, StateIdle)
+
+	// The unfinished sentence is flushed at the tool boundary
+	if got := ttsProvider.requests(); len(got) != 2 || got[0] != "Let me check" || got[1] != "It is sunny." {
+		t.Errorf("expected each response to be synthesized separately, got %q", got)
+	}
+
+	history := agent.chatCtx.History()
+	want := []struct {
+		role    llm.MessageRole
+		content string
+	}{
+		{llm.RoleUser, "What's the weather?"},
+		{llm.RoleAssistant, "Let me check"},
+		{llm.RoleTool, "sunny"},
+		{llm.RoleAssistant, "It is sunny."},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("expected %d messages in history, got %+v", len(want), history)
+	}
+	for i, w := range want {
+		if history[i].Role != w.role || history[i].Content != w.content {
+			t.Errorf("message %d: expected %s %q, got %s %q", i, w.role, w.content, history[i].Role, history[i].Content)
+		}
+	}
+	if len(history[1].ToolCalls) != 1 {
+		t.Errorf("expected the tool call with the first reply, got %+v", history[1])
+	}
+}
+
+// alignedTTS reports a 100ms timing for each word and emits 100ms of audio per word.
+type alignedTTS struct {
+	recordingTTS
+}
+
+func (a *alignedTTS) SynthesizeStream(ctx context.Context, req tts.SynthesizeRequest) (tts.SynthesizeStream, error) {
+	return tts.NewSentenceStream(ctx, req, a.synthesizeSentence), nil
+}
+
+func (a *alignedTTS) synthesizeSentence(ctx context.Context, req tts.SynthesizeRequest, frames chan<- rtc.AudioFrame, words chan<- tts.WordTiming) error {
+	offset := 0
+	for i, word := range strings.Fields(req.Text) {
+		offset += strings.Index(req.Text[offset:], word)
+		start := time.Duration(i) * 100 * time.Millisecond
+		select {
+		case words <- tts.WordTiming{Word: word, Offset: offset, Start: start, End: start + 100*time.Millisecond}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		offset += len(word)
+
+		for j := 0; j < 10; j++ {
+			frame := rtc.AudioFrame{Data: make([]byte, 960), SampleRate: 48000, SamplesPerChannel: 480, NumChannels: 1}
+			select {
+			case frames <- frame:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
+// TestAgent_InterruptTruncatesAtAlignedWord verifies that the reply in history
+// is cut after the last word the TTS reported as played, and that each played
+// word is captioned.
+func TestAgent_InterruptTruncatesAtAlignedWord(t *testing.T) {
+	ttsOut := make(chan rtc.AudioFrame)
+
+	agent, err := New(Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          &alignedTTS{},
+		LLM:          fake.NewFakeLLM(),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       ttsOut,
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	var captionsMu sync.Mutex
+	var captions []string
+	On(agent, func(e AgentTranscriptEvent) {
+		captionsMu.Lock()
+		defer captionsMu.Unlock()
+		captions = append(captions, e.Word)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := agent.startSpeaking(ctx, "one two three four"); err != nil {
+		t.Fatalf("startSpeaking failed: %v", err)
+	}
+
+	// Play 250ms of audio, which ends part way through the third word
+	for i := 0; i < 25; i++ {
+		select {
+		case <-ttsOut:
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for TTS audio")
+		}
+	}
+
+	agent.turnMu.Lock()
+	speech := agent.speech
+	agent.turnMu.Unlock()
+
+	if err := agent.handleInterrupt(ctx); err != nil {
+		t.Fatalf("handleInterrupt failed: %v", err)
+	}
+	select {
+	case <-speech.done:
+	case <-time.After(time.Second):
+		t.Fatal("speech did not stop after interruption")
+	}
+
+	history := agent.chatCtx.History()
+	if len(history) != 1 || history[0].Content != "one two" {
+		t.Fatalf("expected history truncated to %q, got %+v", "one two", history)
+	}
+
+	deadline := time.After(time.Second)
+	for {
+		captionsMu.Lock()
+		got := append([]string(nil), captions...)
+		captionsMu.Unlock()
+		if len(got) == 3 {
+			if got[0] != "one" || got[1] != "two" || got[2] != "three" {
+				t.Errorf("unexpected captions: %q", got)
+			}
+			return
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("expected captions for the 3 started words, got %q", got)
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+// flakyTTS fails the first failures requests with err after emitting frames
+// frames, then synthesizes normally.
+type flakyTTS struct {
+	recordingTTS
+	failures int
+	frames   int
+	err      error
+}
+
+func (f *flakyTTS) SynthesizeStream(ctx context.Context, req tts.SynthesizeRequest) (tts.SynthesizeStream, error) {
+	return tts.NewSentenceStream(ctx, req, tts.WithEstimatedTimings(f.synthesizeSentence)), nil
+}
+
+func (f *flakyTTS) synthesizeSentence(ctx context.Context, req tts.SynthesizeRequest, frames chan<- rtc.AudioFrame, words chan<- tts.WordTiming) error {
+	f.recordingTTS.Synthesize(ctx, req)
+
+	f.mu.Lock()
+	fail := len(f.texts) <= f.failures
+	f.mu.Unlock()
+
+	count := 1
+	var err error
+	if fail {
+		count, err = f.frames, f.err
+	}
+	for i := 0; i < count; i++ {
+		frame := rtc.AudioFrame{Data: make([]byte, 960), SampleRate: 48000, SamplesPerChannel: 480, NumChannels: 1}
+		select {
+		case frames <- frame:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// TestAgent_RetriesRecoverableTTSFailure verifies that a segment that failed
+// before any of its audio was played is synthesized again.
+func TestAgent_RetriesRecoverableTTSFailure(t *testing.T) {
+	provider := &flakyTTS{failures: 1, err: ai.NewRecoverableError(errors.New("reset"), "connection reset")}
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.TTS = provider
+		cfg.TTSRetry = &ai.RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond}
+		cfg.TTSFallbackMessage = "Sorry, something went wrong."
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := agent.Say(ctx, "Hello there.", SayOptions{}).Wait(ctx); err != nil {
+		t.Fatalf("expected the retried speech to succeed, got %v", err)
+	}
+	if got := provider.requests(); len(got) != 2 || got[0] != "Hello there." || got[1] != "Hello there." {
+		t.Errorf("expected the segment to be synthesized twice, got %q", got)
+	}
+	if history := agent.chatCtx.History(); len(history) != 1 || history[0].Content != "Hello there." {
+		t.Errorf("expected the full text in history, got %+v", history)
+	}
+}
+
+// TestAgent_TTSFailureSpeaksFallback verifies that a fatal failure part way
+// through a segment stops the utterance and speaks the fallback message.
+func TestAgent_TTSFailureSpeaksFallback(t *testing.T) {
+	provider := &flakyTTS{failures: 1, frames: 5, err: ai.NewFatalError(errors.New("quota"), "quota exceeded")}
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.TTS = provider
+		cfg.TTSRetry = &ai.RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond}
+		cfg.TTSFallbackMessage = "Sorry, something went wrong."
+	})
+
+	rec := &eventRecorder{}
+	agent.Subscribe(rec.record)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	err := agent.Say(ctx, "A reply that fails.", SayOptions{}).Wait(ctx)
+	if !ai.IsFatal(err) {
+		t.Errorf("expected the fatal TTS error from Wait, got %v", err)
+	}
+	if got := provider.requests(); len(got) != 2 || got[1] != "Sorry, something went wrong." {
+		t.Errorf("expected no retry and the fallback message, got %q", got)
+	}
+	rec.waitFor(t, EventError)
+	// 50ms of audio is shorter than the first word, so nothing was heard
+	if history := agent.chatCtx.History(); len(history) != 0 {
+		t.Errorf("expected no assistant message in history, got %+v", history)
+	}
+}
@@ -0,0 +1,155 @@
+package agent
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/stt"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/tts"
+)
+
+// Audio defaults used when AudioOptions leaves a field unset.
+const (
+	defaultSampleRate  = 48000
+	defaultNumChannels = 1
+	defaultLanguage    = "en-US"
+)
+
+// AudioOptions describes the microphone audio the agent receives, the audio it
+// writes to TTSOut and the voice it speaks with. The options are passed to the
+// STT stream and every TTS request, and are validated against the providers'
+// capabilities when the agent is created. Audio is resampled automatically
+// when a provider does not support the microphone or output format.
+type AudioOptions struct {
+	SampleRate  int // Sample rate of the microphone audio in Hz (defaults to 48000)
+	NumChannels int // Channel count of the microphone audio (defaults to 1)
+
+	OutputSampleRate int // Sample rate of the frames written to TTSOut (defaults to 48000)
+	OutputChannels   int // Channel count of the frames written to TTSOut (defaults to 1)
+
+	Language string  // BCP-47 language for STT, TTS and turn detection (defaults to Config.Language, then "en-US")
+	Voice    string  // TTS voice (optional, empty uses the provider's default)
+	Speed    float32 // Speaking rate multiplier (optional, 0 uses the provider's default)
+	Pitch    float32 // Pitch adjustment (optional, 0 uses the provider's default)
+}
+
+// withDefaults fills in unset options. language is the legacy Config.Language.
+func (o AudioOptions) withDefaults(language string) AudioOptions {
+	if o.SampleRate == 0 {
+		o.SampleRate = defaultSampleRate
+	}
+	if o.NumChannels == 0 {
+		o.NumChannels = defaultNumChannels
+	}
+	if o.OutputSampleRate == 0 {
+		o.OutputSampleRate = defaultSampleRate
+	}
+	if o.OutputChannels == 0 {
+		o.OutputChannels = defaultNumChannels
+	}
+	if o.Language == "" {
+		o.Language = language
+	}
+	if o.Language == "" {
+		o.Language = defaultLanguage
+	}
+	return o
+}
+
+// validateFormat checks the microphone and output formats. The output is
+// produced in 10 ms frames, so its rate must be a multiple of 100 Hz.
+func (o AudioOptions) validateFormat() error {
+	if o.SampleRate < 0 || o.NumChannels < 0 {
+		return fmt.Errorf("invalid audio format: %d Hz, %d channels", o.SampleRate, o.NumChannels)
+	}
+	if o.OutputSampleRate < 0 || o.OutputSampleRate%100 != 0 || o.OutputChannels < 0 {
+		return fmt.Errorf("invalid output format: %d Hz, %d channels", o.OutputSampleRate, o.OutputChannels)
+	}
+	return nil
+}
+
+// validateSTT checks the language against the STT provider. Empty capability
+// lists are treated as unrestricted.
+func (o AudioOptions) validateSTT(caps stt.STTCapabilities) error {
+	if !languageSupported(o.Language, caps.SupportedLanguages) {
+		return fmt.Errorf("STT does not support language %q", o.Language)
+	}
+	return nil
+}
+
+// validateTTS checks the language, voice and prosody options against a TTS
+// provider. voice is validated separately from o.Voice since tasks can change it.
+func (o AudioOptions) validateTTS(caps tts.TTSCapabilities, voice string) error {
+	if !languageSupported(o.Language, caps.SupportedLanguages) {
+		return fmt.Errorf("TTS does not support language %q", o.Language)
+	}
+	if voice != "" && len(caps.SupportedVoices) > 0 && !slices.Contains(caps.SupportedVoices, voice) {
+		return fmt.Errorf("TTS does not support voice %q (supported: %v)", voice, caps.SupportedVoices)
+	}
+	if o.Speed < 0 {
+		return fmt.Errorf("invalid speed %v", o.Speed)
+	}
+	if o.Speed != 0 && !caps.SupportsSpeedControl {
+		return fmt.Errorf("TTS does not support speed control")
+	}
+	if o.Pitch != 0 && !caps.SupportsPitchControl {
+		return fmt.Errorf("TTS does not support pitch control")
+	}
+	return nil
+}
+
+// languageSupported reports whether a language tag is in the supported list.
+// Tags match case-insensitively and a provider listing only the primary
+// language ("en") supports every regional variant ("en-US").
+func languageSupported(language string, supported []string) bool {
+	if len(supported) == 0 {
+		return true
+	}
+	primary, _, _ := strings.Cut(language, "-")
+	for _, s := range supported {
+		if strings.EqualFold(s, language) || strings.EqualFold(s, primary) {
+			return true
+		}
+	}
+	return false
+}
+
+// supportedSampleRate returns rate if a provider supports it, otherwise the
+// supported rate audio is resampled to: the lowest rate above it, or the
+// highest rate if none is. Rates that do not divide into 10 ms frames are
+// skipped. An empty list means any rate is supported.
+func supportedSampleRate(rate int, supported []int) int {
+	if len(supported) == 0 || slices.Contains(supported, rate) {
+		return rate
+	}
+	above, highest := 0, 0
+	for _, s := range supported {
+		if s%100 != 0 {
+			continue
+		}
+		if s > rate && (above == 0 || s < above) {
+			above = s
+		}
+		highest = max(highest, s)
+	}
+	switch {
+	case above != 0:
+		return above
+	case highest != 0:
+		return highest
+	default:
+		return rate
+	}
+}
+
+// synthesizeRequest builds a TTS request for text with the agent's audio options.
+func (a *Agent) synthesizeRequest(text, voice string) tts.SynthesizeRequest {
+	return tts.SynthesizeRequest{
+		Text:     text,
+		Voice:    voice,
+		Language: a.audio.Language,
+		Speed:    a.audio.Speed,
+		Pitch:    a.audio.Pitch,
+	}
+}
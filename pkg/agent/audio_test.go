@@ -0,0 +1,356 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm/fake"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/stt"
+	sttfake "github.com/chriscow/livekit-agents-go/pkg/ai/stt/fake"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/tts"
+	ttsfake "github.com/chriscow/livekit-agents-go/pkg/ai/tts/fake"
+	vadfake "github.com/chriscow/livekit-agents-go/pkg/ai/vad/fake"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+	turnfake "github.com/chriscow/livekit-agents-go/pkg/turn/fake"
+)
+
+// recordingSTT records the configuration of the streams it opens and the
+// sample rates of the frames pushed to them.
+type recordingSTT struct {
+	*sttfake.FakeSTT
+	mu    sync.Mutex
+	cfg   stt.StreamConfig
+	rates []int
+}
+
+func (r *recordingSTT) NewStream(ctx context.Context, cfg stt.StreamConfig) (stt.STTStream, error) {
+	r.mu.Lock()
+	r.cfg = cfg
+	r.mu.Unlock()
+	stream, err := r.FakeSTT.NewStream(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingSTTStream{STTStream: stream, stt: r}, nil
+}
+
+func (r *recordingSTT) pushedRates() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.rates...)
+}
+
+type recordingSTTStream struct {
+	stt.STTStream
+	stt *recordingSTT
+}
+
+func (s *recordingSTTStream) Push(frame rtc.AudioFrame) error {
+	s.stt.mu.Lock()
+	s.stt.rates = append(s.stt.rates, frame.SampleRate)
+	s.stt.mu.Unlock()
+	return s.STTStream.Push(frame)
+}
+
+// chunkedTTS emits 24 kHz audio in chunks that are not 10 ms long.
+type chunkedTTS struct {
+	recordingTTS
+}
+
+func (c *chunkedTTS) Synthesize(ctx context.Context, req tts.SynthesizeRequest) (<-chan rtc.AudioFrame, error) {
+	frames := make(chan rtc.AudioFrame, 2)
+	for i := 0; i < 2; i++ {
+		frames <- rtc.AudioFrame{
+			Data:              make([]byte, 2048),
+			SampleRate:        24000,
+			SamplesPerChannel: 1024,
+			NumChannels:       1,
+		}
+	}
+	close(frames)
+	return frames, nil
+}
+
+// prosodyTTS is a recordingTTS that supports speed and pitch control.
+type prosodyTTS struct {
+	recordingTTS
+}
+
+func (p *prosodyTTS) Capabilities() tts.TTSCapabilities {
+	return tts.TTSCapabilities{
+		SupportedLanguages:   []string{"es"},
+		SupportedVoices:      []string{"lucia"},
+		SampleRates:          []int{48000},
+		SupportsSpeedControl: true,
+		SupportsPitchControl: true,
+	}
+}
+
+func TestAgent_AudioOptions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	recognizer := &recordingSTT{FakeSTT: sttfake.NewFakeSTT("hola")}
+	synthesizer := &prosodyTTS{}
+	ttsOut := make(chan rtc.AudioFrame, 10)
+	agent, err := New(Config{
+		STT:          recognizer,
+		TTS:          synthesizer,
+		LLM:          fake.NewFakeLLM(),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       ttsOut,
+		Audio: AudioOptions{
+			SampleRate: 16000,
+			Language:   "es-ES",
+			Voice:      "lucia",
+			Speed:      1.25,
+			Pitch:      0.9,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	if err := agent.startListening(ctx); err != nil {
+		t.Fatalf("startListening failed: %v", err)
+	}
+	defer agent.stopListening(ctx)
+
+	recognizer.mu.Lock()
+	cfg := recognizer.cfg
+	recognizer.mu.Unlock()
+	if cfg.SampleRate != 16000 || cfg.NumChannels != 1 || cfg.Lang != "es-ES" {
+		t.Errorf("unexpected STT stream config: %+v", cfg)
+	}
+
+	speech, err := agent.speakInterim(ctx, "Hola.", StateIdle)
+	if err != nil {
+		t.Fatalf("speakInterim failed: %v", err)
+	}
+	<-ttsOut
+	<-speech.done
+
+	synthesizer.mu.Lock()
+	req := synthesizer.lastReq
+	synthesizer.mu.Unlock()
+	want := tts.SynthesizeRequest{Text: "Hola.", Voice: "lucia", Language: "es-ES", Speed: 1.25, Pitch: 0.9}
+	if req != want {
+		t.Errorf("expected TTS request %+v, got %+v", want, req)
+	}
+}
+
+func TestAgent_AudioOptionsDefaults(t *testing.T) {
+	agent := newTestAgent(t, nil)
+	want := AudioOptions{SampleRate: 48000, NumChannels: 1, OutputSampleRate: 48000, OutputChannels: 1, Language: "en-US"}
+	if agent.audio != want {
+		t.Errorf("expected default options %+v, got %+v", want, agent.audio)
+	}
+
+	// The legacy Language field still applies when Audio.Language is unset
+	legacy := newTestAgent(t, func(cfg *Config) { cfg.Language = "en-GB" })
+	if legacy.audio.Language != "en-GB" {
+		t.Errorf("expected Config.Language to be used, got %q", legacy.audio.Language)
+	}
+}
+
+func TestNew_InvalidAudioOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		audio AudioOptions
+		want  string
+	}{
+		{"output rate not divisible into frames", AudioOptions{OutputSampleRate: 22050}, "invalid output format"},
+		{"unsupported language", AudioOptions{Language: "fr-FR"}, `STT does not support language "fr-FR"`},
+		{"unsupported voice", AudioOptions{Voice: "alloy"}, `voice "alloy"`},
+		{"negative speed", AudioOptions{Speed: -1}, "invalid speed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(Config{
+				STT:          sttfake.NewFakeSTT("test"),
+				TTS:          ttsfake.NewFakeTTS(),
+				LLM:          fake.NewFakeLLM(),
+				VAD:          vadfake.NewFakeVAD(0.3),
+				TurnDetector: turnfake.NewFakeTurnDetector(),
+				MicIn:        make(chan rtc.AudioFrame),
+				TTSOut:       make(chan rtc.AudioFrame),
+				Audio:        tt.audio,
+			})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// Pitch is rejected by a TTS without pitch control
+	_, err := New(Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          &recordingTTS{},
+		LLM:          fake.NewFakeLLM(),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       make(chan rtc.AudioFrame),
+		Audio:        AudioOptions{Pitch: 1.1},
+	})
+	if err == nil || !strings.Contains(err.Error(), "pitch control") {
+		t.Errorf("expected pitch control error, got %v", err)
+	}
+}
+
+func TestAgent_UpdateTaskValidatesVoice(t *testing.T) {
+	agent := newTestAgent(t, nil)
+
+	err := agent.UpdateTask(context.Background(), &AgentTask{Name: "spanish", TTS: ttsfake.NewFakeTTS(), Voice: "alloy"})
+	if err == nil || !strings.Contains(err.Error(), "voice") {
+		t.Errorf("expected the unsupported voice to be rejected, got %v", err)
+	}
+	if agent.Task().Name == "spanish" {
+		t.Error("rejected task should not become active")
+	}
+}
+
+func TestLanguageSupported(t *testing.T) {
+	tests := []struct {
+		language  string
+		supported []string
+		want      bool
+	}{
+		{"en-US", nil, true},
+		{"en-US", []string{"en-US", "es-ES"}, true},
+		{"en-us", []string{"en-US"}, true},
+		{"en-US", []string{"en", "fr"}, true},
+		{"en-GB", []string{"en-US"}, false},
+		{"de-DE", []string{"en", "fr"}, false},
+	}
+	for _, tt := range tests {
+		if got := languageSupported(tt.language, tt.supported); got != tt.want {
+			t.Errorf("languageSupported(%q, %v) = %v, want %v", tt.language, tt.supported, got, tt.want)
+		}
+	}
+}
+
+func TestAgent_ResamplesMicrophoneForSTT(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// The fake STT and VAD support 16 and 48 kHz, not 24 kHz
+	recognizer := &recordingSTT{FakeSTT: sttfake.NewFakeSTT("hello")}
+	micIn := make(chan rtc.AudioFrame)
+	agent, err := New(Config{
+		STT:          recognizer,
+		TTS:          &recordingTTS{},
+		LLM:          fake.NewFakeLLM(),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        micIn,
+		TTSOut:       make(chan rtc.AudioFrame),
+		Audio:        AudioOptions{SampleRate: 24000},
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	if agent.sttSampleRate != 48000 || agent.vadSampleRate != 48000 {
+		t.Fatalf("expected audio to be resampled to 48 kHz, got STT %d and VAD %d", agent.sttSampleRate, agent.vadSampleRate)
+	}
+
+	if err := agent.startListening(ctx); err != nil {
+		t.Fatalf("startListening failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		frame, _ := rtc.NewAudioFrame(make([]byte, 480), 24000, 1, 0)
+		micIn <- *frame
+	}
+	if err := agent.stopListening(ctx); err != nil {
+		t.Fatalf("stopListening failed: %v", err)
+	}
+
+	recognizer.mu.Lock()
+	cfg := recognizer.cfg
+	recognizer.mu.Unlock()
+	if cfg.SampleRate != 48000 {
+		t.Errorf("expected the STT stream to be opened at 48 kHz, got %d", cfg.SampleRate)
+	}
+	rates := recognizer.pushedRates()
+	if len(rates) != 5 {
+		t.Errorf("expected 50 ms of audio in 5 frames, got %d frames", len(rates))
+	}
+	for _, rate := range rates {
+		if rate != 48000 {
+			t.Errorf("expected 48 kHz frames, got %v", rates)
+			break
+		}
+	}
+}
+
+func TestAgent_ConvertsTTSOutput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	ttsOut := make(chan rtc.AudioFrame, 100)
+	agent, err := New(Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          &chunkedTTS{},
+		LLM:          fake.NewFakeLLM(),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       ttsOut,
+		Audio:        AudioOptions{OutputSampleRate: 16000, OutputChannels: 2},
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	speech, err := agent.speakInterim(ctx, "Hello.", StateIdle)
+	if err != nil {
+		t.Fatalf("speakInterim failed: %v", err)
+	}
+	<-speech.done
+	close(ttsOut)
+
+	// 2048 samples at 24 kHz are 85.3 ms, padded to 9 frames
+	var frames []rtc.AudioFrame
+	for frame := range ttsOut {
+		frames = append(frames, frame)
+	}
+	if len(frames) != 9 {
+		t.Fatalf("expected 9 frames, got %d", len(frames))
+	}
+	for _, frame := range frames {
+		if frame.SampleRate != 16000 || frame.NumChannels != 2 || len(frame.Data) != 640 {
+			t.Fatalf("unexpected frame: %d Hz, %d channels, %d bytes", frame.SampleRate, frame.NumChannels, len(frame.Data))
+		}
+	}
+}
+
+func TestSupportedSampleRate(t *testing.T) {
+	tests := []struct {
+		rate      int
+		supported []int
+		want      int
+	}{
+		{48000, nil, 48000},
+		{48000, []int{16000, 48000}, 48000},
+		{24000, []int{16000, 48000}, 48000},
+		{48000, []int{8000, 16000}, 16000},
+		{16000, []int{22050, 24000}, 24000},
+		{48000, []int{22050, 44100}, 44100},
+		{48000, []int{22050}, 48000},
+	}
+	for _, tt := range tests {
+		if got := supportedSampleRate(tt.rate, tt.supported); got != tt.want {
+			t.Errorf("supportedSampleRate(%d, %v) = %d, want %d", tt.rate, tt.supported, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package agent
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of agent event.
+type EventType string
+
+const (
+	// EventStateChanged is fired when the agent's state machine changes state
+	EventStateChanged EventType = "state_changed"
+
+	// EventUserTranscript is fired for interim and final user transcripts
+	EventUserTranscript EventType = "user_transcript"
+
+	// EventSpeechStarted is fired when the agent starts speaking
+	EventSpeechStarted EventType = "speech_started"
+
+	// EventSpeechFinished is fired when the agent finishes speaking
+	EventSpeechFinished EventType = "speech_finished"
+
+	// EventSpeechInterrupted is fired when the agent's speech is interrupted
+	EventSpeechInterrupted EventType = "speech_interrupted"
+
+	// EventAgentTranscript is fired as each word of the agent's speech starts playing
+	EventAgentTranscript EventType = "agent_transcript"
+
+	// EventToolCallStarted is fired before a tool is executed
+	EventToolCallStarted EventType = "tool_call_started"
+
+	// EventToolCallFinished is fired after a tool has been executed
+	EventToolCallFinished EventType = "tool_call_finished"
+
+	// EventMetricsCollected is fired when the agent records new metrics
+	EventMetricsCollected EventType = "metrics_collected"
+
+	// EventHandoff is fired when the agent switches to a new task
+	EventHandoff EventType = "handoff"
+
+	// EventError is fired when a component reports an error
+	EventError EventType = "error"
+)
+
+// Event is implemented by all agent events.
+type Event interface {
+	// Type returns the kind of event.
+	Type() EventType
+	// Time returns when the event occurred.
+	Time() time.Time
+}
+
+// StateChangedEvent reports a state machine transition.
+type StateChangedEvent struct {
+	OldState  AgentState
+	NewState  AgentState
+	Timestamp time.Time
+}
+
+// UserTranscriptEvent reports an interim, preflight or final transcript of
+// the user's speech. Confidence and SpeakerID come from the best alternative,
+// if the STT provider reports them.
+type UserTranscriptEvent struct {
+	Text       string
+	IsFinal    bool
+	Language   string
+	Confidence float32
+	SpeakerID  string
+	Timestamp  time.Time
+}
+
+// SpeechStartedEvent reports that the agent started speaking.
+type SpeechStartedEvent struct {
+	Timestamp time.Time
+}
+
+// SpeechFinishedEvent reports that the agent finished speaking.
+type SpeechFinishedEvent struct {
+	Text      string // Full text that was spoken
+	Timestamp time.Time
+}
+
+// SpeechInterruptedEvent reports that the agent's speech was cut off.
+type SpeechInterruptedEvent struct {
+	Text       string // Full text that was handed to TTS
+	PlayedText string // Text that was played before the interruption
+	Timestamp  time.Time
+}
+
+// AgentTranscriptEvent reports a word of the agent's speech as its audio starts
+// playing, for captions synchronized with the audio.
+type AgentTranscriptEvent struct {
+	Word      string
+	Timestamp time.Time
+}
+
+// ToolCallStartedEvent reports that a tool is about to run.
+type ToolCallStartedEvent struct {
+	CallID    string // ID of the tool call, shared with its finished event
+	Name      string
+	Arguments string
+	Timestamp time.Time
+}
+
+// ToolCallFinishedEvent reports the outcome of a tool call.
+type ToolCallFinishedEvent struct {
+	CallID    string
+	Name      string
+	Arguments string
+	Result    string
+	Error     error
+	Duration  time.Duration
+	Timestamp time.Time
+}
+
+// MetricsCollectedEvent reports metric values recorded by the agent, keyed by
+// the same names used for the expvar metrics (e.g. "end_of_utterance_delay_ms").
+type MetricsCollectedEvent struct {
+	Metrics   map[string]float64
+	Timestamp time.Time
+}
+
+// HandoffEvent reports that the agent switched to a new task.
+type HandoffEvent struct {
+	From      string // Name of the previous task
+	To        string // Name of the new task
+	Timestamp time.Time
+}
+
+// ErrorEvent reports an error from one of the agent's components.
+type ErrorEvent struct {
+	Source    string // Component that failed: "stt", "tts", "llm", "vad", "turn" or "tool"
+	Error     error
+	Timestamp time.Time
+}
+
+func (e StateChangedEvent) Type() EventType      { return EventStateChanged }
+func (e UserTranscriptEvent) Type() EventType    { return EventUserTranscript }
+func (e SpeechStartedEvent) Type() EventType     { return EventSpeechStarted }
+func (e SpeechFinishedEvent) Type() EventType    { return EventSpeechFinished }
+func (e SpeechInterruptedEvent) Type() EventType { return EventSpeechInterrupted }
+func (e AgentTranscriptEvent) Type() EventType   { return EventAgentTranscript }
+func (e ToolCallStartedEvent) Type() EventType   { return EventToolCallStarted }
+func (e ToolCallFinishedEvent) Type() EventType  { return EventToolCallFinished }
+func (e MetricsCollectedEvent) Type() EventType  { return EventMetricsCollected }
+func (e HandoffEvent) Type() EventType           { return EventHandoff }
+func (e ErrorEvent) Type() EventType             { return EventError }
+
+func (e StateChangedEvent) Time() time.Time      { return e.Timestamp }
+func (e UserTranscriptEvent) Time() time.Time    { return e.Timestamp }
+func (e SpeechStartedEvent) Time() time.Time     { return e.Timestamp }
+func (e SpeechFinishedEvent) Time() time.Time    { return e.Timestamp }
+func (e SpeechInterruptedEvent) Time() time.Time { return e.Timestamp }
+func (e AgentTranscriptEvent) Time() time.Time   { return e.Timestamp }
+func (e ToolCallStartedEvent) Time() time.Time   { return e.Timestamp }
+func (e ToolCallFinishedEvent) Time() time.Time  { return e.Timestamp }
+func (e MetricsCollectedEvent) Time() time.Time  { return e.Timestamp }
+func (e HandoffEvent) Time() time.Time           { return e.Timestamp }
+func (e ErrorEvent) Time() time.Time             { return e.Timestamp }
+
+// subscriberBufferSize is the number of events buffered per subscriber before
+// new events are dropped.
+const subscriberBufferSize = 64
+
+// eventBus fans agent events out to subscribers. Each subscriber has its own
+// buffered queue and goroutine so that a slow handler never blocks the agent.
+type eventBus struct {
+	mu     sync.Mutex
+	subs   map[int]*subscriber
+	nextID int
+	closed bool
+}
+
+// subscriber delivers events to a single handler.
+type subscriber struct {
+	events chan Event
+	done   chan struct{}
+}
+
+// newEventBus creates an empty event bus.
+func newEventBus() *eventBus {
+	return &eventBus{subs: make(map[int]*subscriber)}
+}
+
+// subscribe registers handler and returns a function that removes it.
+func (b *eventBus) subscribe(handler func(Event)) func() {
+	sub := &subscriber{
+		events: make(chan Event, subscriberBufferSize),
+		done:   make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return func() {}
+	}
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = sub
+	b.mu.Unlock()
+
+	go func() {
+		defer close(sub.done)
+		for event := range sub.events {
+			handler(event)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			if _, ok := b.subs[id]; ok {
+				delete(b.subs, id)
+				close(sub.events)
+			}
+			b.mu.Unlock()
+		})
+	}
+}
+
+// emit delivers an event to all subscribers without blocking.
+func (b *eventBus) emit(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subs {
+		select {
+		case sub.events <- event:
+		default:
+			slog.Warn("dropping agent event for slow subscriber", slog.String("event", string(event.Type())))
+		}
+	}
+}
+
+// close removes all subscribers. Pending events are still delivered.
+func (b *eventBus) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for id, sub := range b.subs {
+		delete(b.subs, id)
+		close(sub.events)
+	}
+}
+
+// Subscribe registers a handler that receives every agent event. Handlers run
+// on a dedicated goroutine in the order events were emitted; events are dropped
+// if a handler falls too far behind. The returned function unsubscribes.
+func (a *Agent) Subscribe(handler func(Event)) (unsubscribe func()) {
+	return a.events.subscribe(handler)
+}
+
+// On registers a handler for a single event type, for example:
+//
+//	agent.On(a, func(e agent.UserTranscriptEvent) { ... })
+//
+// The returned function unsubscribes.
+func On[T Event](a *Agent, handler func(T)) (unsubscribe func()) {
+	return a.Subscribe(func(event Event) {
+		if e, ok := event.(T); ok {
+			handler(e)
+		}
+	})
+}
+
+// emitError publishes an ErrorEvent for the given component.
+func (a *Agent) emitError(source string, err error) {
+	a.events.emit(ErrorEvent{Source: source, Error: err, Timestamp: time.Now()})
+}
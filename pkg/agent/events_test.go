@@ -0,0 +1,237 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm/fake"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/stt"
+	sttfake "github.com/chriscow/livekit-agents-go/pkg/ai/stt/fake"
+	vadfake "github.com/chriscow/livekit-agents-go/pkg/ai/vad/fake"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+	turnfake "github.com/chriscow/livekit-agents-go/pkg/turn/fake"
+)
+
+// eventRecorder collects events delivered to a subscriber.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) record(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) types() []EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	types := make([]EventType, len(r.events))
+	for i, e := range r.events {
+		types[i] = e.Type()
+	}
+	return types
+}
+
+// waitFor polls until an event of the given type has been recorded.
+func (r *eventRecorder) waitFor(t *testing.T, eventType EventType) {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		for _, got := range r.types() {
+			if got == eventType {
+				return
+			}
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for %s event, got %v", eventType, r.types())
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+func TestAgent_Events(t *testing.T) {
+	ttsOut := make(chan rtc.AudioFrame, 10)
+	agent, err := New(Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          &recordingTTS{},
+		LLM:          fake.NewFakeLLM("Hello!"),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       ttsOut,
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	go func() {
+		for {
+			select {
+			case <-ttsOut:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	all := &eventRecorder{}
+	agent.Subscribe(all.record)
+
+	var transcripts []string
+	var transcriptsMu sync.Mutex
+	On(agent, func(e UserTranscriptEvent) {
+		transcriptsMu.Lock()
+		defer transcriptsMu.Unlock()
+		transcripts = append(transcripts, e.Text)
+	})
+
+	agent.setState(StateThinking)
+	if err := agent.handleSTTEvent(ctx, stt.SpeechEvent{Type: stt.SpeechEventFinal, Text: "hi there", IsFinal: true}); err != nil {
+		t.Fatalf("handleSTTEvent failed: %v", err)
+	}
+
+	all.waitFor(t, EventSpeechFinished)
+
+	transcriptsMu.Lock()
+	if len(transcripts) != 1 || transcripts[0] != "hi there" {
+		t.Errorf("expected one typed transcript event, got %q", transcripts)
+	}
+	transcriptsMu.Unlock()
+
+	// Metrics and caption events are interleaved depending on timing, so only
+	// check the lifecycle events
+	want := []EventType{EventStateChanged, EventUserTranscript, EventStateChanged, EventSpeechStarted, EventSpeechFinished}
+	var got []EventType
+	for _, eventType := range all.types() {
+		if eventType != EventMetricsCollected && eventType != EventAgentTranscript {
+			got = append(got, eventType)
+		}
+	}
+	if len(got) < len(want) {
+		t.Fatalf("expected at least %d events, got %v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d: expected %s, got %s (all: %v)", i, want[i], got[i], got)
+		}
+	}
+}
+
+func TestAgent_PreflightTranscriptEvent(t *testing.T) {
+	agent, err := New(Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          &recordingTTS{},
+		LLM:          fake.NewFakeLLM(),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       make(chan rtc.AudioFrame),
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	transcripts := make(chan UserTranscriptEvent, 1)
+	On(agent, func(e UserTranscriptEvent) { transcripts <- e })
+
+	agent.setState(StateListening)
+	event := stt.SpeechEvent{
+		Type:         stt.SpeechEventPreflightTranscript,
+		Text:         "book a table",
+		Alternatives: []stt.SpeechData{{Text: "book a table", Confidence: 0.8, SpeakerID: "S1"}},
+	}
+	if err := agent.handleSTTEvent(context.Background(), event); err != nil {
+		t.Fatalf("handleSTTEvent failed: %v", err)
+	}
+
+	select {
+	case got := <-transcripts:
+		if got.Text != "book a table" || got.IsFinal || got.Confidence != 0.8 || got.SpeakerID != "S1" {
+			t.Errorf("unexpected transcript event %+v", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the transcript event")
+	}
+	if state := agent.GetState(); state != StateListening {
+		t.Errorf("expected a preflight transcript to leave the agent listening, got %s", state)
+	}
+}
+
+func TestAgent_EventsUnsubscribe(t *testing.T) {
+	agent, err := New(Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          &recordingTTS{},
+		LLM:          fake.NewFakeLLM(),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       make(chan rtc.AudioFrame),
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	rec := &eventRecorder{}
+	unsubscribe := agent.Subscribe(rec.record)
+
+	agent.setState(StateListening)
+	rec.waitFor(t, EventStateChanged)
+
+	unsubscribe()
+	unsubscribe() // safe to call twice
+	agent.setState(StateThinking)
+	time.Sleep(20 * time.Millisecond)
+
+	if got := rec.types(); len(got) != 1 {
+		t.Errorf("expected no events after unsubscribe, got %v", got)
+	}
+}
+
+func TestAgent_ToolCallEvents(t *testing.T) {
+	agent, err := New(Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          &recordingTTS{},
+		LLM:          fake.NewFakeLLM(),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       make(chan rtc.AudioFrame),
+		Tools: []Tool{{
+			Name: "lookup",
+			Handler: func(ctx context.Context, args string) (string, error) {
+				return "found", nil
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	rec := &eventRecorder{}
+	agent.Subscribe(rec.record)
+
+	agent.handleToolCalls(context.Background(), "", []llm.ToolCall{{ID: "call_1", Name: "lookup", Arguments: "{}"}})
+	rec.waitFor(t, EventToolCallFinished)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	finished := rec.events[len(rec.events)-1].(ToolCallFinishedEvent)
+	if finished.Name != "lookup" || finished.Result != "found" || finished.Error != nil {
+		t.Errorf("unexpected tool call event: %+v", finished)
+	}
+	if rec.events[0].Type() != EventToolCallStarted {
+		t.Errorf("expected tool call started first, got %s", rec.events[0].Type())
+	}
+}
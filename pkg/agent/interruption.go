@@ -0,0 +1,215 @@
+package agent
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+)
+
+// InterruptionPolicy controls when user speech interrupts the agent while it is speaking.
+// The zero value interrupts on any detected speech, which matches the behavior of
+// agents created without a policy.
+type InterruptionPolicy struct {
+	// Disabled prevents the user from interrupting the agent. Microphone frames
+	// are discarded through the agent's AudioGate while TTS is playing.
+	Disabled bool
+
+	// MinDuration is the minimum duration of user speech before the agent is
+	// interrupted. Shorter sounds such as coughs are ignored.
+	MinDuration time.Duration
+
+	// MinWords is the minimum number of words in the user's interim transcript
+	// before the agent is interrupted. STT runs while the agent is speaking so
+	// that words can be counted.
+	MinWords int
+
+	// FalseInterruptionTimeout pauses playback on interruption instead of
+	// stopping it. If no transcript arrives within the timeout the interruption
+	// is treated as false and playback resumes where it left off.
+	FalseInterruptionTimeout time.Duration
+}
+
+// pendingInterruption tracks user speech that started while the agent was
+// speaking but has not yet satisfied the interruption policy.
+type pendingInterruption struct {
+	start time.Time
+	words int
+}
+
+// handleUserSpeechWhileSpeaking applies the interruption policy when VAD detects
+// user speech during playback.
+func (a *Agent) handleUserSpeechWhileSpeaking(ctx context.Context) error {
+	policy := a.interruption
+	if policy.Disabled {
+		return nil
+	}
+
+	a.turnMu.Lock()
+	uninterruptible := a.speech != nil && a.speech.opts.Uninterruptible
+	a.turnMu.Unlock()
+	if uninterruptible {
+		return nil
+	}
+	if policy.MinDuration <= 0 && policy.MinWords <= 0 {
+		return a.handleBargeIn(ctx)
+	}
+
+	pending := &pendingInterruption{start: time.Now()}
+	a.policyMu.Lock()
+	a.pending = pending
+	a.policyMu.Unlock()
+
+	if policy.MinWords > 0 {
+		// Transcribe the user's speech so words can be counted
+		if err := a.startListening(ctx); err != nil {
+			return err
+		}
+	}
+
+	if policy.MinDuration > 0 {
+		time.AfterFunc(policy.MinDuration, func() {
+			a.policyMu.Lock()
+			defer a.policyMu.Unlock()
+			if a.pending == pending {
+				a.checkPendingLocked()
+			}
+		})
+	}
+	return nil
+}
+
+// cancelPendingInterruption discards user speech that ended before it satisfied
+// the interruption policy.
+func (a *Agent) cancelPendingInterruption(ctx context.Context) error {
+	a.policyMu.Lock()
+	hadPending := a.pending != nil
+	a.pending = nil
+	a.policyMu.Unlock()
+
+	if hadPending && a.interruption.MinWords > 0 {
+		return a.stopListening(ctx)
+	}
+	return nil
+}
+
+// checkPendingLocked signals a barge-in once the pending interruption satisfies
+// both the duration and the word thresholds. policyMu must be held.
+func (a *Agent) checkPendingLocked() {
+	if a.pending == nil {
+		return
+	}
+	if time.Since(a.pending.start) < a.interruption.MinDuration || a.pending.words < a.interruption.MinWords {
+		return
+	}
+
+	a.pending = nil
+	select {
+	case a.bargeIns <- struct{}{}:
+	default:
+		// Barge-in already pending
+	}
+}
+
+// handleBargeIn interrupts the agent because the user started talking. With a
+// false interruption timeout the current speech is paused rather than cancelled.
+func (a *Agent) handleBargeIn(ctx context.Context) error {
+	if a.GetState() != StateSpeaking {
+		return nil
+	}
+
+	timeout := a.interruption.FalseInterruptionTimeout
+	if timeout <= 0 {
+		return a.handleInterrupt(ctx)
+	}
+
+	a.turnMu.Lock()
+	speech := a.speech
+	a.turnMu.Unlock()
+	if speech == nil {
+		return a.handleInterrupt(ctx)
+	}
+
+	speech.pause()
+	a.policyMu.Lock()
+	a.pausedSpeech = speech
+	a.policyMu.Unlock()
+
+	a.setState(StateListening)
+	if err := a.continueListening(ctx); err != nil {
+		return err
+	}
+
+	go func() {
+		select {
+		case <-time.After(timeout):
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case a.resumes <- speech:
+		case <-ctx.Done():
+		case <-a.shutdown:
+		}
+	}()
+	return nil
+}
+
+// handleResume resumes paused speech if no transcript arrived before the false
+// interruption timeout expired.
+func (a *Agent) handleResume(ctx context.Context, speech *speechHandle) error {
+	a.policyMu.Lock()
+	if a.pausedSpeech != speech {
+		// The interruption was confirmed by a transcript
+		a.policyMu.Unlock()
+		return nil
+	}
+	a.pausedSpeech = nil
+	a.policyMu.Unlock()
+
+	log.Printf("▶️ False interruption detected, resuming speech")
+	if err := a.stopListening(ctx); err != nil {
+		return err
+	}
+	a.setState(StateSpeaking)
+	speech.resume()
+	return nil
+}
+
+// observeTranscript feeds a user transcript into the interruption policy. It
+// counts words towards a pending interruption and confirms a paused interruption.
+func (a *Agent) observeTranscript(ctx context.Context, text string) error {
+	words := len(strings.Fields(text))
+	if words == 0 {
+		return nil
+	}
+
+	a.policyMu.Lock()
+	if a.pending != nil && words > a.pending.words {
+		a.pending.words = words
+		a.checkPendingLocked()
+	}
+	paused := a.pausedSpeech
+	a.pausedSpeech = nil
+	userSpeaking := a.userSpeaking
+	a.policyMu.Unlock()
+
+	if paused == nil {
+		return nil
+	}
+
+	// The user really is talking, so the paused speech is abandoned
+	a.cancelTurn()
+	if !userSpeaking && a.GetState() == StateListening {
+		// Speech already ended while we were waiting for the transcript
+		return a.handleSpeechEnd(ctx)
+	}
+	return nil
+}
+
+// isSpeechPaused reports whether speech is paused awaiting a possible false interruption.
+func (a *Agent) isSpeechPaused() bool {
+	a.policyMu.Lock()
+	defer a.policyMu.Unlock()
+	return a.pausedSpeech != nil
+}
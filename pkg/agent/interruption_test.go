@@ -0,0 +1,230 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sttfake "github.com/chriscow/livekit-agents-go/pkg/ai/stt/fake"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/vad"
+)
+
+func TestInterruptionPolicy_Disabled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.STT = sttfake.NewFakeSTT("stop please")
+		cfg.Interruption = InterruptionPolicy{Disabled: true}
+	})
+	if err := agent.startSpeaking(ctx, "A fairly long answer that keeps the agent busy for a while."); err != nil {
+		t.Fatalf("startSpeaking failed: %v", err)
+	}
+
+	if agent.audioGate == nil {
+		t.Fatal("expected a default audio gate when interruptions are disabled")
+	}
+	if !agent.audioGate.ShouldDiscardAudio() {
+		t.Error("expected microphone audio to be discarded while speaking")
+	}
+
+	if err := agent.handleVADEvent(ctx, vad.VADEvent{Type: vad.VADEventSpeechStart}); err != nil {
+		t.Fatalf("handleVADEvent failed: %v", err)
+	}
+	if agent.GetState() != StateSpeaking {
+		t.Errorf("expected agent to keep speaking, got %v", agent.GetState())
+	}
+
+	agent.cancelTurn()
+	agent.turnMu.Lock()
+	speech := agent.speech
+	agent.turnMu.Unlock()
+	if speech != nil {
+		<-speech.done
+	}
+	if agent.audioGate.ShouldDiscardAudio() {
+		t.Error("expected microphone audio to pass once speech finished")
+	}
+}
+
+func TestInterruptionPolicy_MinDuration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.STT = sttfake.NewFakeSTT("stop please")
+		cfg.Interruption = InterruptionPolicy{MinDuration: 50 * time.Millisecond}
+	})
+	if err := agent.startSpeaking(ctx, "A fairly long answer that keeps the agent busy for a while."); err != nil {
+		t.Fatalf("startSpeaking failed: %v", err)
+	}
+
+	// A short cough ends before the minimum duration
+	if err := agent.handleVADEvent(ctx, vad.VADEvent{Type: vad.VADEventSpeechStart}); err != nil {
+		t.Fatalf("handleVADEvent failed: %v", err)
+	}
+	if err := agent.handleVADEvent(ctx, vad.VADEvent{Type: vad.VADEventSpeechEnd}); err != nil {
+		t.Fatalf("handleVADEvent failed: %v", err)
+	}
+	select {
+	case <-agent.bargeIns:
+		t.Fatal("short speech should not interrupt the agent")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Sustained speech interrupts once the minimum duration has passed
+	if err := agent.handleVADEvent(ctx, vad.VADEvent{Type: vad.VADEventSpeechStart}); err != nil {
+		t.Fatalf("handleVADEvent failed: %v", err)
+	}
+	select {
+	case <-agent.bargeIns:
+	case <-time.After(time.Second):
+		t.Fatal("sustained speech should interrupt the agent")
+	}
+	if err := agent.handleBargeIn(ctx); err != nil {
+		t.Fatalf("handleBargeIn failed: %v", err)
+	}
+	if agent.GetState() != StateListening {
+		t.Errorf("expected Listening state after interruption, got %v", agent.GetState())
+	}
+}
+
+func TestInterruptionPolicy_MinWords(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.STT = sttfake.NewFakeSTT("stop please")
+		cfg.Interruption = InterruptionPolicy{MinWords: 2}
+	})
+	if err := agent.startSpeaking(ctx, "A fairly long answer that keeps the agent busy for a while."); err != nil {
+		t.Fatalf("startSpeaking failed: %v", err)
+	}
+
+	if err := agent.handleVADEvent(ctx, vad.VADEvent{Type: vad.VADEventSpeechStart}); err != nil {
+		t.Fatalf("handleVADEvent failed: %v", err)
+	}
+
+	if err := agent.observeTranscript(ctx, "mm-hm"); err != nil {
+		t.Fatalf("observeTranscript failed: %v", err)
+	}
+	select {
+	case <-agent.bargeIns:
+		t.Fatal("a single word should not interrupt the agent")
+	default:
+	}
+
+	if err := agent.observeTranscript(ctx, "wait stop"); err != nil {
+		t.Fatalf("observeTranscript failed: %v", err)
+	}
+	select {
+	case <-agent.bargeIns:
+	default:
+		t.Fatal("two words should interrupt the agent")
+	}
+}
+
+func TestInterruptionPolicy_FalseInterruption(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.STT = sttfake.NewFakeSTT("stop please")
+		cfg.Interruption = InterruptionPolicy{FalseInterruptionTimeout: 50 * time.Millisecond}
+	})
+	if err := agent.startSpeaking(ctx, "A fairly long answer that keeps the agent busy for a while."); err != nil {
+		t.Fatalf("startSpeaking failed: %v", err)
+	}
+
+	agent.turnMu.Lock()
+	speech := agent.speech
+	agent.turnMu.Unlock()
+
+	if err := agent.handleBargeIn(ctx); err != nil {
+		t.Fatalf("handleBargeIn failed: %v", err)
+	}
+	if agent.GetState() != StateListening {
+		t.Fatalf("expected Listening state while paused, got %v", agent.GetState())
+	}
+	if !agent.isSpeechPaused() {
+		t.Fatal("expected speech to be paused")
+	}
+
+	// No transcript arrives, so playback resumes after the timeout
+	select {
+	case resumed := <-agent.resumes:
+		if err := agent.handleResume(ctx, resumed); err != nil {
+			t.Fatalf("handleResume failed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected speech to resume after the timeout")
+	}
+
+	if agent.GetState() != StateSpeaking {
+		t.Errorf("expected Speaking state after resume, got %v", agent.GetState())
+	}
+	if speech.isInterrupted() {
+		t.Error("resumed speech should not be marked interrupted")
+	}
+
+	// A real interruption is confirmed by a transcript
+	if err := agent.handleBargeIn(ctx); err != nil {
+		t.Fatalf("handleBargeIn failed: %v", err)
+	}
+	if err := agent.observeTranscript(ctx, "hold on"); err != nil {
+		t.Fatalf("observeTranscript failed: %v", err)
+	}
+	select {
+	case <-speech.done:
+	case <-time.After(time.Second):
+		t.Fatal("expected speech to stop once the interruption was confirmed")
+	}
+	if !speech.isInterrupted() {
+		t.Error("expected speech to be marked interrupted")
+	}
+}
+
+// TestInterruptionPolicy_BargeInKeepsSTTStream verifies that the STT stream
+// opened to count the user's words keeps transcribing after the barge-in,
+// whether the speech is stopped or paused.
+func TestInterruptionPolicy_BargeInKeepsSTTStream(t *testing.T) {
+	policies := map[string]InterruptionPolicy{
+		"stop":  {MinWords: 1},
+		"pause": {MinWords: 1, FalseInterruptionTimeout: time.Second},
+	}
+	for name, policy := range policies {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			agent := newTestAgent(t, func(cfg *Config) {
+				cfg.STT = sttfake.NewFakeSTT("stop please")
+				cfg.Interruption = policy
+			})
+			if err := agent.startSpeaking(ctx, "A fairly long answer that keeps the agent busy for a while."); err != nil {
+				t.Fatalf("startSpeaking failed: %v", err)
+			}
+			if err := agent.handleVADEvent(ctx, vad.VADEvent{Type: vad.VADEventSpeechStart}); err != nil {
+				t.Fatalf("handleVADEvent failed: %v", err)
+			}
+			agent.streamMu.Lock()
+			stream := agent.sttStream
+			agent.streamMu.Unlock()
+			if stream == nil {
+				t.Fatal("expected an STT stream to count words")
+			}
+
+			if err := agent.handleBargeIn(ctx); err != nil {
+				t.Fatalf("handleBargeIn failed: %v", err)
+			}
+			if agent.GetState() != StateListening {
+				t.Errorf("expected Listening state after the barge-in, got %v", agent.GetState())
+			}
+			agent.streamMu.Lock()
+			defer agent.streamMu.Unlock()
+			if agent.sttStream != stream {
+				t.Error("expected the barge-in to keep the open STT stream")
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+)
+
+// ErrAgentClosed is returned for speech that was still queued when the agent was closed.
+var ErrAgentClosed = errors.New("agent closed")
+
+// SayOptions configures speech scheduled with Say or GenerateReply.
+type SayOptions struct {
+	// Uninterruptible prevents user speech from interrupting this speech.
+	// Interrupt and SpeechHandle.Interrupt still stop it.
+	Uninterruptible bool
+
+	// SkipChatContext keeps the spoken text out of the chat context
+	SkipChatContext bool
+}
+
+// SpeechHandle tracks speech scheduled with Say or GenerateReply. Speech is
+// queued until the agent is idle, so a handle may wait before it starts playing.
+type SpeechHandle struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   func() bool // stops interrupting the speech when the caller's context ends
+	done   chan struct{}
+
+	text         string // Text to speak (Say)
+	instructions string // Instructions for the reply (GenerateReply)
+	reply        bool
+	opts         SayOptions
+
+	mu          sync.Mutex
+	speech      *speechHandle
+	interrupted bool
+	err         error
+}
+
+// Done returns a channel that is closed once the speech has finished playing,
+// was interrupted or failed.
+func (h *SpeechHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait blocks until the speech is done and returns the error that stopped it,
+// if any. Interrupted speech is not an error.
+func (h *SpeechHandle) Wait(ctx context.Context) error {
+	select {
+	case <-h.done:
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		return h.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Interrupt stops the speech, or removes it from the queue if it has not started.
+func (h *SpeechHandle) Interrupt() {
+	h.mu.Lock()
+	h.interrupted = true
+	speech := h.speech
+	h.mu.Unlock()
+
+	if speech != nil {
+		speech.interrupt()
+	}
+	h.cancel()
+}
+
+// Interrupted reports whether the speech was interrupted, either through
+// Interrupt or by the user.
+func (h *SpeechHandle) Interrupted() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.interrupted || (h.speech != nil && h.speech.isInterrupted())
+}
+
+// setSpeech attaches the utterance that plays the speech.
+func (h *SpeechHandle) setSpeech(speech *speechHandle) {
+	h.mu.Lock()
+	h.speech = speech
+	interrupted := h.interrupted
+	h.mu.Unlock()
+
+	if interrupted {
+		speech.interrupt()
+	}
+}
+
+// finish records the outcome and releases waiters.
+func (h *SpeechHandle) finish(err error) {
+	h.mu.Lock()
+	if h.interrupted {
+		err = nil
+	}
+	h.err = err
+	h.mu.Unlock()
+
+	h.stop()
+	h.cancel()
+	close(h.done)
+}
+
+// Say speaks text through the agent's TTS and background audio. The speech is
+// queued behind other scheduled speech and starts once the agent is idle.
+// Cancelling ctx interrupts the speech.
+func (a *Agent) Say(ctx context.Context, text string, opts SayOptions) *SpeechHandle {
+	return a.schedule(ctx, &SpeechHandle{text: text, opts: opts})
+}
+
+// GenerateReply asks the LLM for a reply to the conversation and speaks it.
+// Instructions apply to this reply only, for example "Greet the user". The
+// reply is queued like speech from Say.
+func (a *Agent) GenerateReply(ctx context.Context, instructions string) *SpeechHandle {
+	return a.schedule(ctx, &SpeechHandle{instructions: instructions, reply: true})
+}
+
+// schedule adds speech to the queue.
+func (a *Agent) schedule(ctx context.Context, h *SpeechHandle) *SpeechHandle {
+	h.ctx, h.cancel = context.WithCancel(ctx)
+	h.done = make(chan struct{})
+	h.stop = context.AfterFunc(ctx, h.Interrupt)
+
+	select {
+	case <-a.shutdown:
+		h.finish(ErrAgentClosed)
+		return h
+	default:
+	}
+
+	a.queueMu.Lock()
+	a.speechQueue = append(a.speechQueue, h)
+	a.queueMu.Unlock()
+
+	select {
+	case a.queueSignal <- struct{}{}:
+	default:
+		// Queue already signalled
+	}
+	return h
+}
+
+// nextQueuedSpeech removes the oldest speech from the queue.
+func (a *Agent) nextQueuedSpeech() *SpeechHandle {
+	a.queueMu.Lock()
+	defer a.queueMu.Unlock()
+	if len(a.speechQueue) == 0 {
+		return nil
+	}
+	h := a.speechQueue[0]
+	a.speechQueue = a.speechQueue[1:]
+	return h
+}
+
+// runSpeechQueue plays scheduled speech one at a time until the agent is closed.
+func (a *Agent) runSpeechQueue() {
+	for {
+		h := a.nextQueuedSpeech()
+		if h == nil {
+			select {
+			case <-a.queueSignal:
+				continue
+			case <-a.shutdown:
+				return
+			}
+		}
+
+		select {
+		case <-a.shutdown:
+			h.finish(ErrAgentClosed)
+			for h = a.nextQueuedSpeech(); h != nil; h = a.nextQueuedSpeech() {
+				h.finish(ErrAgentClosed)
+			}
+			return
+		default:
+		}
+
+		if err := a.waitForIdle(h.ctx); err != nil {
+			h.finish(err)
+			continue
+		}
+		h.finish(a.playScheduled(h))
+	}
+}
+
+// waitForIdle blocks until the agent is idle, so that scheduled speech never
+// talks over the user or an in-flight reply.
+func (a *Agent) waitForIdle(ctx context.Context) error {
+	for {
+		a.stateMu.Lock()
+		changed := a.stateChanged
+		a.stateMu.Unlock()
+
+		if a.GetState() == StateIdle {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-a.shutdown:
+			return ErrAgentClosed
+		}
+	}
+}
+
+type speechObserverKey struct{}
+
+// playScheduled speaks a queued handle and waits for playback to finish.
+func (a *Agent) playScheduled(h *SpeechHandle) error {
+	var err error
+	if h.reply {
+		// Observe the utterance started by the LLM turn so we can wait for it
+		ctx := context.WithValue(h.ctx, speechObserverKey{}, h.setSpeech)
+		a.setState(StateThinking)
+		thinkCtx := a.beginThinking(ctx)
+		err = a.generateLLMReply(thinkCtx, h.instructions)
+		if err != nil && thinkCtx.Err() != nil {
+			// The user or the caller interrupted the reply before it was spoken
+			h.mu.Lock()
+			h.interrupted = true
+			h.mu.Unlock()
+			err = nil
+		} else if err != nil {
+			log.Printf("❌ Generated reply failed: %v", err)
+			a.emitError("llm", err)
+		}
+	} else {
+		var speech *speechHandle
+		speech, err = a.beginSpeech(h.ctx, h.opts)
+		if err == nil {
+			h.setSpeech(speech)
+			err = a.speakText(speech, h.text)
+		}
+	}
+
+	h.mu.Lock()
+	speech := h.speech
+	h.mu.Unlock()
+	if speech != nil {
+		<-speech.done
+		if err == nil {
+			err = speech.failure()
+		}
+	}
+
+	// Speech stopped through its handle does not go through handleInterrupt,
+	// so the agent is returned to idle here
+	if h.ctx.Err() != nil || err != nil {
+		if state := a.GetState(); state == StateThinking || state == StateSpeaking {
+			a.setState(StateIdle)
+		}
+	}
+	return err
+}
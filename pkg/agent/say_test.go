@@ -0,0 +1,132 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm/fake"
+	"github.com/chriscow/livekit-agents-go/pkg/metrics"
+)
+
+func TestAgent_Say(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	synthesizer := &recordingTTS{}
+	agent := newTestAgent(t, func(cfg *Config) { cfg.TTS = synthesizer })
+
+	if err := agent.Say(ctx, "Welcome!", SayOptions{}).Wait(ctx); err != nil {
+		t.Fatalf("Say failed: %v", err)
+	}
+	if err := agent.Say(ctx, "Not recorded.", SayOptions{SkipChatContext: true}).Wait(ctx); err != nil {
+		t.Fatalf("Say failed: %v", err)
+	}
+
+	if got := synthesizer.requests(); len(got) != 2 || got[0] != "Welcome!" {
+		t.Errorf("expected both texts to be synthesized in order, got %q", got)
+	}
+	history := agent.chatCtx.History()
+	if len(history) != 1 || history[0].Role != llm.RoleAssistant || history[0].Content != "Welcome!" {
+		t.Errorf("expected only the first text in the chat context, got %+v", history)
+	}
+	if agent.GetState() != StateIdle {
+		t.Errorf("expected Idle state after speaking, got %v", agent.GetState())
+	}
+}
+
+func TestAgent_SayWaitsForIdle(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	synthesizer := &recordingTTS{}
+	agent := newTestAgent(t, func(cfg *Config) { cfg.TTS = synthesizer })
+
+	// The user is talking, so the greeting is held back
+	agent.setState(StateListening)
+	first := agent.Say(ctx, "First.", SayOptions{})
+	second := agent.Say(ctx, "Second.", SayOptions{})
+	dropped := agent.Say(ctx, "Dropped.", SayOptions{})
+	dropped.Interrupt()
+
+	time.Sleep(50 * time.Millisecond)
+	if got := synthesizer.requests(); len(got) != 0 {
+		t.Fatalf("expected speech to wait for the agent to become idle, got %q", got)
+	}
+
+	agent.setState(StateIdle)
+	for _, h := range []*SpeechHandle{first, second, dropped} {
+		if err := h.Wait(ctx); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+	}
+
+	if got := synthesizer.requests(); len(got) != 2 || got[0] != "First." || got[1] != "Second." {
+		t.Errorf("expected queued speech in order without the interrupted one, got %q", got)
+	}
+	if !dropped.Interrupted() || first.Interrupted() {
+		t.Error("expected only the dropped speech to be interrupted")
+	}
+}
+
+func TestAgent_GenerateReply(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	synthesizer := &recordingTTS{}
+	model := &recordingLLM{LLM: fake.NewFakeLLM("Hello, how can I help?")}
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.TTS = synthesizer
+		cfg.LLM = model
+	})
+
+	h := agent.GenerateReply(ctx, "Greet the user.")
+	if err := h.Wait(ctx); err != nil {
+		t.Fatalf("GenerateReply failed: %v", err)
+	}
+
+	if got := synthesizer.requests(); len(got) != 1 || got[0] != "Hello, how can I help?" {
+		t.Errorf("expected the reply to be spoken, got %q", got)
+	}
+
+	model.mu.Lock()
+	defer model.mu.Unlock()
+	if len(model.requests) != 1 {
+		t.Fatalf("expected 1 LLM request, got %d", len(model.requests))
+	}
+	messages := model.requests[0]
+	last := messages[len(messages)-1]
+	if last.Role != llm.RoleSystem || last.Content != "Greet the user." {
+		t.Errorf("expected reply instructions at the end of the request, got %+v", last)
+	}
+	for _, msg := range agent.chatCtx.History() {
+		if msg.Content == "Greet the user." {
+			t.Error("reply instructions should not be stored in the chat context")
+		}
+	}
+}
+
+func TestAgent_GenerateReplyRecordsUsage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	usage := metrics.NewCollector()
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.TTS = &recordingTTS{}
+		cfg.LLM = fake.NewFakeLLM("Hello, how can I help?")
+		cfg.Usage = usage
+	})
+
+	if err := agent.GenerateReply(ctx, "Greet the user.").Wait(ctx); err != nil {
+		t.Fatalf("GenerateReply failed: %v", err)
+	}
+
+	summary := usage.Summary()
+	if summary.LLMRequests != 1 || summary.PromptTokens != 10 || summary.CompletionTokens != 5 || summary.TotalTokens != 15 {
+		t.Errorf("expected the LLM tokens of the reply, got %+v", summary)
+	}
+	if summary.TTSRequests != 1 || summary.TTSCharacters != len("Hello, how can I help?") || summary.TTSAudioDuration != 10*time.Millisecond {
+		t.Errorf("expected the characters and audio of the spoken reply, got %+v", summary)
+	}
+}
@@ -0,0 +1,197 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/tts"
+)
+
+// speechHandle tracks a single assistant utterance from synthesis through playout.
+// Each utterance owns a cancellable context so that an interruption stops both
+// synthesis and playback immediately.
+type speechHandle struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	opts   SayOptions
+	after  AgentState // State to enter once the utterance finishes uninterrupted
+
+	mu          sync.Mutex
+	segments    []string // text handed to TTS, in order
+	played      []string // text whose audio reached the output
+	recorded    string   // prefix of the text already stored in the chat context
+	interrupted bool
+	err         error // synthesis failure that stopped the utterance
+	paused      bool
+	resumed     chan struct{} // closed when a paused utterance resumes
+}
+
+// newSpeechHandle creates a speech handle whose context is derived from parent.
+func newSpeechHandle(parent context.Context, opts SayOptions) *speechHandle {
+	ctx, cancel := context.WithCancel(parent)
+	return &speechHandle{
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+		opts:   opts,
+		after:  StateIdle,
+	}
+}
+
+// interrupt cancels synthesis and playback of the utterance.
+func (h *speechHandle) interrupt() {
+	h.mu.Lock()
+	h.interrupted = true
+	h.mu.Unlock()
+	h.cancel()
+}
+
+// isInterrupted reports whether the utterance was interrupted.
+func (h *speechHandle) isInterrupted() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.interrupted
+}
+
+// fail records the synthesis failure that stopped the utterance.
+func (h *speechHandle) fail(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.err = err
+}
+
+// failure returns the synthesis failure that stopped the utterance, if any.
+func (h *speechHandle) failure() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
+
+// pause holds playback until resume is called or the utterance is cancelled.
+func (h *speechHandle) pause() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.paused {
+		h.paused = true
+		h.resumed = make(chan struct{})
+	}
+}
+
+// resume continues playback of a paused utterance.
+func (h *speechHandle) resume() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.paused {
+		h.paused = false
+		close(h.resumed)
+	}
+}
+
+// waitResumed blocks while the utterance is paused. It returns false if the
+// utterance was cancelled while waiting.
+func (h *speechHandle) waitResumed() bool {
+	h.mu.Lock()
+	if !h.paused {
+		h.mu.Unlock()
+		return true
+	}
+	resumed := h.resumed
+	h.mu.Unlock()
+
+	select {
+	case <-resumed:
+		return true
+	case <-h.ctx.Done():
+		return false
+	}
+}
+
+// addSegment records a piece of text that is about to be synthesized.
+func (h *speechHandle) addSegment(text string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.segments = append(h.segments, text)
+}
+
+// markPlayed records how much of a segment was played. A segment that was cut
+// off is truncated after the last word whose audio reached the output.
+func (h *speechHandle) markPlayed(text string, timings []tts.WordTiming, playedAudio time.Duration, complete bool) {
+	if !complete {
+		text = tts.SpokenText(text, timings, playedAudio)
+	}
+	if text == "" {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.played = append(h.played, text)
+}
+
+// text returns the full text handed to TTS.
+func (h *speechHandle) text() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return strings.Join(h.segments, " ")
+}
+
+// playedText returns the text that was actually played to the user.
+func (h *speechHandle) playedText() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return strings.Join(h.played, " ")
+}
+
+// markRecorded records that text, a prefix of the utterance's text, was
+// already stored in the chat context, such as a reply spoken before the LLM
+// called tools.
+func (h *speechHandle) markRecorded(text string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.recorded = text
+}
+
+// unrecorded returns the part of text, a prefix of the utterance's text, that
+// is not stored in the chat context yet.
+func (h *speechHandle) unrecorded(text string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(text) <= len(h.recorded) {
+		return ""
+	}
+	return strings.TrimSpace(text[len(h.recorded):])
+}
+
+// wordTimings collects the word timings of a segment, which a TTS session
+// reports while it synthesizes.
+type wordTimings struct {
+	mu    sync.Mutex
+	words []tts.WordTiming
+}
+
+// collectWords collects the timings received from words until it is closed.
+func collectWords(words <-chan tts.WordTiming) *wordTimings {
+	timings := &wordTimings{}
+	go func() {
+		for word := range words {
+			timings.add(word)
+		}
+	}()
+	return timings
+}
+
+func (w *wordTimings) add(timing tts.WordTiming) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.words = append(w.words, timing)
+}
+
+// snapshot returns the timings received so far.
+func (w *wordTimings) snapshot() []tts.WordTiming {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]tts.WordTiming(nil), w.words...)
+}
@@ -0,0 +1,240 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/tts"
+)
+
+// ChatContextMode controls what happens to the conversation history when the
+// agent hands off to a new task.
+type ChatContextMode int
+
+const (
+	// ChatContextKeep carries the full history over to the new task
+	ChatContextKeep ChatContextMode = iota
+
+	// ChatContextSummarize replaces the history with an LLM-generated summary
+	ChatContextSummarize
+
+	// ChatContextReset starts the new task with an empty history
+	ChatContextReset
+)
+
+func (m ChatContextMode) String() string {
+	switch m {
+	case ChatContextKeep:
+		return "Keep"
+	case ChatContextSummarize:
+		return "Summarize"
+	case ChatContextReset:
+		return "Reset"
+	default:
+		return fmt.Sprintf("Unknown(%d)", m)
+	}
+}
+
+// AgentTask defines a persona the agent can switch to mid-session, for example
+// a receptionist that hands the caller over to a billing specialist. Components
+// left nil keep the ones currently in use.
+type AgentTask struct {
+	Name         string          // Name used in logs and handoff events
+	Instructions string          // System prompt for the task (empty keeps the current one)
+	Tools        []Tool          // Tools available to the task (replaces the current tools)
+	LLM          llm.LLM         // LLM for the task (optional)
+	TTS          tts.TTS         // TTS for the task (optional)
+	Voice        string          // TTS voice for the task (optional)
+	ChatContext  ChatContextMode // How the conversation history carries over
+}
+
+// summaryPrompt asks the LLM to condense the history before a handoff.
+const summaryPrompt = "Summarize the conversation so far in a few sentences for the agent taking over. " +
+	"Include the user's goal and any details they have provided."
+
+type agentContextKey struct{}
+
+// withAgent returns a context carrying the agent, as passed to tool handlers.
+func withAgent(ctx context.Context, a *Agent) context.Context {
+	return context.WithValue(ctx, agentContextKey{}, a)
+}
+
+// FromContext returns the agent executing the current tool call, or nil if ctx
+// was not passed to a tool handler by an agent.
+func FromContext(ctx context.Context) *Agent {
+	a, _ := ctx.Value(agentContextKey{}).(*Agent)
+	return a
+}
+
+// HandoffTool creates a tool that switches the agent to the task returned by
+// next. The LLM continues the current turn as the new task, so it can greet
+// the user or answer the request that triggered the handoff.
+func HandoffTool(name, description string, next func(ctx context.Context, args string) (*AgentTask, error)) Tool {
+	return Tool{
+		Name:        name,
+		Description: description,
+		Schema: map[string]any{
+			"type":       "object",
+			"properties": map[string]any{},
+		},
+		Handler: func(ctx context.Context, args string) (string, error) {
+			a := FromContext(ctx)
+			if a == nil {
+				return "", fmt.Errorf("handoff tool %s called outside of an agent", name)
+			}
+			task, err := next(ctx, args)
+			if err != nil {
+				return "", err
+			}
+			if err := a.UpdateTask(ctx, task); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Transferred to %s.", task.Name), nil
+		},
+	}
+}
+
+// Task returns the task the agent is currently running.
+func (a *Agent) Task() *AgentTask {
+	a.taskMu.RLock()
+	defer a.taskMu.RUnlock()
+	return a.task
+}
+
+// UpdateTask switches the agent to a new task. The task's instructions, tools
+// and components apply from the next LLM request, so a handoff made from a tool
+// call takes effect within the same turn.
+func (a *Agent) UpdateTask(ctx context.Context, task *AgentTask) error {
+	if task == nil {
+		return fmt.Errorf("task is required")
+	}
+
+	// Reject a TTS or voice that cannot speak with the agent's audio options
+	if task.TTS != nil || task.Voice != "" {
+		synthesizer, voice := a.currentTTS()
+		if task.TTS != nil {
+			synthesizer = task.TTS
+		}
+		if task.Voice != "" {
+			voice = task.Voice
+		}
+		if err := a.audio.validateTTS(synthesizer.Capabilities(), voice); err != nil {
+			return fmt.Errorf("invalid TTS for task %s: %w", task.Name, err)
+		}
+	}
+
+	// Summarize before taking the lock, it requires an LLM round trip. Only
+	// the summarized messages are replaced, messages added meanwhile are kept.
+	var summary string
+	var summarized int
+	if task.ChatContext == ChatContextSummarize {
+		model := task.LLM
+		if model == nil {
+			model = a.currentLLM()
+		}
+		history := a.chatCtx.History()
+		summarized = len(history)
+		var err error
+		summary, err = a.summarizeHistory(ctx, model, history)
+		if err != nil {
+			return fmt.Errorf("failed to summarize chat context: %w", err)
+		}
+	}
+
+	toolsMap := make(map[string]Tool)
+	for _, tool := range task.Tools {
+		toolsMap[tool.Name] = tool
+	}
+
+	a.taskMu.Lock()
+	previous := a.task
+	a.task = task
+	a.tools = toolsMap
+	if task.LLM != nil {
+		a.llm = task.LLM
+	}
+	if task.TTS != nil {
+		a.tts = task.TTS
+	}
+	if task.Voice != "" {
+		a.voice = task.Voice
+	}
+	a.taskMu.Unlock()
+
+	if task.Instructions != "" {
+		a.chatCtx.SetInstructions(task.Instructions)
+	}
+	switch task.ChatContext {
+	case ChatContextSummarize:
+		if summary != "" {
+			a.chatCtx.ReplacePrefix(summarized, llm.Message{
+				Role:    llm.RoleSystem,
+				Content: "Summary of the conversation so far: " + summary,
+			})
+		} else {
+			a.chatCtx.ReplacePrefix(summarized)
+		}
+	case ChatContextReset:
+		a.chatCtx.ReplaceHistory()
+	}
+
+	var from string
+	if previous != nil {
+		from = previous.Name
+	}
+	log.Printf("🔀 Handing off from %q to %q (chat context: %v)", from, task.Name, task.ChatContext)
+	a.events.emit(HandoffEvent{From: from, To: task.Name, Timestamp: time.Now()})
+	return nil
+}
+
+// summarizeHistory asks the LLM for a short summary of the conversation.
+func (a *Agent) summarizeHistory(ctx context.Context, model llm.LLM, history []llm.Message) (string, error) {
+	var transcript strings.Builder
+	for _, msg := range history {
+		if msg.Content == "" || msg.Role == llm.RoleFunction || msg.Role == llm.RoleTool {
+			continue
+		}
+		fmt.Fprintf(&transcript, "%s: %s\n", msg.Role, msg.Content)
+	}
+	if transcript.Len() == 0 {
+		return "", nil
+	}
+
+	response, err := model.Chat(ctx, llm.ChatRequest{
+		Messages: []llm.Message{
+			{Role: llm.RoleSystem, Content: summaryPrompt},
+			{Role: llm.RoleUser, Content: transcript.String()},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	a.recordLLMUsage(response.TokensUsed, response.PromptTokens, response.CompletionTokens)
+	return strings.TrimSpace(response.Message.Content), nil
+}
+
+// currentLLM returns the LLM of the active task.
+func (a *Agent) currentLLM() llm.LLM {
+	a.taskMu.RLock()
+	defer a.taskMu.RUnlock()
+	return a.llm
+}
+
+// currentTTS returns the TTS and voice of the active task.
+func (a *Agent) currentTTS() (tts.TTS, string) {
+	a.taskMu.RLock()
+	defer a.taskMu.RUnlock()
+	return a.tts, a.voice
+}
+
+// currentTool looks up a tool of the active task by name.
+func (a *Agent) currentTool(name string) (Tool, bool) {
+	a.taskMu.RLock()
+	defer a.taskMu.RUnlock()
+	tool, ok := a.tools[name]
+	return tool, ok
+}
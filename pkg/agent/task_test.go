@@ -0,0 +1,212 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm/fake"
+	sttfake "github.com/chriscow/livekit-agents-go/pkg/ai/stt/fake"
+	vadfake "github.com/chriscow/livekit-agents-go/pkg/ai/vad/fake"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+	turnfake "github.com/chriscow/livekit-agents-go/pkg/turn/fake"
+)
+
+// TestAgent_HandoffTool verifies that a tool call can switch the agent to a new
+// task and that the new task answers within the same turn.
+func TestAgent_HandoffTool(t *testing.T) {
+	ttsOut := make(chan rtc.AudioFrame, 10)
+	receptionistTTS := &recordingTTS{}
+	billingTTS := &recordingTTS{}
+	billingLLM := &recordingLLM{LLM: fake.NewFakeLLM("Billing here.")}
+
+	billing := &AgentTask{
+		Name:         "billing",
+		Instructions: "You are a billing specialist.",
+		LLM:          billingLLM,
+		TTS:          billingTTS,
+		Voice:        "alloy",
+	}
+
+	agent, err := New(Config{
+		STT:          sttfake.NewFakeSTT("test"),
+		TTS:          receptionistTTS,
+		LLM:          fake.NewFakeLLM("Receptionist here."),
+		VAD:          vadfake.NewFakeVAD(0.3),
+		TurnDetector: turnfake.NewFakeTurnDetector(),
+		MicIn:        make(chan rtc.AudioFrame),
+		TTSOut:       ttsOut,
+		Instructions: "You are a receptionist.",
+		Tools: []Tool{
+			HandoffTool("transfer_to_billing", "Transfer the caller to billing", func(ctx context.Context, args string) (*AgentTask, error) {
+				return billing, nil
+			}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	defer agent.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	go func() {
+		for {
+			select {
+			case <-ttsOut:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	handoffs := make(chan HandoffEvent, 1)
+	On(agent, func(e HandoffEvent) { handoffs <- e })
+
+	// The fake LLM calls the first tool when the user mentions a function
+	agent.chatCtx.Append(llm.Message{Role: llm.RoleUser, Content: "function: I have a billing question"})
+	if err := agent.processLLMResponse(ctx); err != nil {
+		t.Fatalf("processLLMResponse failed: %v", err)
+	}
+	waitForState(t, ctx, agent, StateIdle)
+
+	if agent.Task() != billing {
+		t.Fatalf("expected billing task to be active, got %+v", agent.Task())
+	}
+	if _, voiceName := agent.currentTTS(); voiceName != "alloy" {
+		t.Errorf("expected billing voice, got %q", voiceName)
+	}
+
+	select {
+	case e := <-handoffs:
+		if e.To != "billing" {
+			t.Errorf("expected handoff to billing, got %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected a handoff event")
+	}
+
+	if got := receptionistTTS.requests(); len(got) != 0 {
+		t.Errorf("expected receptionist TTS to be unused, got %q", got)
+	}
+	if got := billingTTS.requests(); len(got) != 1 || got[0] != "Billing here." {
+		t.Errorf("expected billing TTS to speak the reply, got %q", got)
+	}
+
+	// The billing LLM sees its own instructions and the carried over history
+	billingLLM.mu.Lock()
+	defer billingLLM.mu.Unlock()
+	if len(billingLLM.requests) != 1 {
+		t.Fatalf("expected 1 billing LLM request, got %d", len(billingLLM.requests))
+	}
+	messages := billingLLM.requests[0]
+	if messages[0].Content != "You are a billing specialist." {
+		t.Errorf("expected billing instructions, got %q", messages[0].Content)
+	}
+	last := messages[len(messages)-1]
+	if last.Role != llm.RoleTool || last.Content != "Transferred to billing." {
+		t.Errorf("expected handoff result at the end of the history, got %+v", last)
+	}
+}
+
+func TestAgent_UpdateTaskChatContextModes(t *testing.T) {
+	newAgent := func() *Agent {
+		agent, err := New(Config{
+			STT:          sttfake.NewFakeSTT("test"),
+			TTS:          &recordingTTS{},
+			LLM:          fake.NewFakeLLM("The user wants a refund."),
+			VAD:          vadfake.NewFakeVAD(0.3),
+			TurnDetector: turnfake.NewFakeTurnDetector(),
+			MicIn:        make(chan rtc.AudioFrame),
+			TTSOut:       make(chan rtc.AudioFrame),
+			Instructions: "You are a receptionist.",
+		})
+		if err != nil {
+			t.Fatalf("failed to create agent: %v", err)
+		}
+		t.Cleanup(func() { agent.Close() })
+
+		agent.chatCtx.Append(
+			llm.Message{Role: llm.RoleUser, Content: "I want my money back"},
+			llm.Message{Role: llm.RoleAssistant, Content: "Let me transfer you."},
+		)
+		return agent
+	}
+	ctx := context.Background()
+
+	t.Run("Summarize", func(t *testing.T) {
+		agent := newAgent()
+		err := agent.UpdateTask(ctx, &AgentTask{
+			Name:         "refunds",
+			Instructions: "You handle refunds.",
+			ChatContext:  ChatContextSummarize,
+		})
+		if err != nil {
+			t.Fatalf("UpdateTask failed: %v", err)
+		}
+
+		history := agent.chatCtx.History()
+		if len(history) != 1 || history[0].Role != llm.RoleSystem {
+			t.Fatalf("expected a single summary message, got %+v", history)
+		}
+		if !strings.Contains(history[0].Content, "The user wants a refund.") {
+			t.Errorf("expected summary in history, got %q", history[0].Content)
+		}
+		if agent.chatCtx.Instructions() != "You handle refunds." {
+			t.Errorf("expected new instructions, got %q", agent.chatCtx.Instructions())
+		}
+	})
+
+	t.Run("SummarizeKeepsNewMessages", func(t *testing.T) {
+		agent := newAgent()
+		// A transcript arrives while the summary is generated
+		model := &hookLLM{LLM: fake.NewFakeLLM("The user wants a refund."), before: func() {
+			agent.chatCtx.Append(llm.Message{Role: llm.RoleUser, Content: "Are you still there?"})
+		}}
+		err := agent.UpdateTask(ctx, &AgentTask{Name: "refunds", LLM: model, ChatContext: ChatContextSummarize})
+		if err != nil {
+			t.Fatalf("UpdateTask failed: %v", err)
+		}
+
+		history := agent.chatCtx.History()
+		if len(history) != 2 || history[0].Role != llm.RoleSystem || history[1].Content != "Are you still there?" {
+			t.Errorf("expected the summary followed by the newer message, got %+v", history)
+		}
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		agent := newAgent()
+		if err := agent.UpdateTask(ctx, &AgentTask{Name: "refunds", ChatContext: ChatContextReset}); err != nil {
+			t.Fatalf("UpdateTask failed: %v", err)
+		}
+		if agent.chatCtx.Len() != 0 {
+			t.Errorf("expected empty history, got %+v", agent.chatCtx.History())
+		}
+		if agent.chatCtx.Instructions() != "You are a receptionist." {
+			t.Errorf("expected instructions to be kept, got %q", agent.chatCtx.Instructions())
+		}
+	})
+
+	t.Run("Keep", func(t *testing.T) {
+		agent := newAgent()
+		if err := agent.UpdateTask(ctx, &AgentTask{Name: "refunds"}); err != nil {
+			t.Fatalf("UpdateTask failed: %v", err)
+		}
+		if agent.chatCtx.Len() != 2 {
+			t.Errorf("expected history to be kept, got %+v", agent.chatCtx.History())
+		}
+	})
+}
+
+// hookLLM calls before ahead of every chat request.
+type hookLLM struct {
+	llm.LLM
+	before func()
+}
+
+func (h *hookLLM) Chat(ctx context.Context, req llm.ChatRequest) (llm.ChatResponse, error) {
+	h.before()
+	return h.LLM.Chat(ctx, req)
+}
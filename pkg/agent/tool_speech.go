@@ -0,0 +1,229 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm"
+)
+
+// defaultFillerDelay is how long a tool may run before its filler is spoken.
+const defaultFillerDelay = 2 * time.Second
+
+// confirmationTimeout bounds how long the agent waits for the user to answer a
+// confirmation prompt once it has been spoken.
+const confirmationTimeout = 15 * time.Second
+
+// Words recognized in the user's answer to a confirmation prompt. Negative
+// words win so that "yes, wait, no" and "please don't" are not taken as consent.
+var (
+	affirmativeAnswers = map[string]bool{
+		"yes": true, "yeah": true, "yep": true, "yup": true, "sure": true,
+		"ok": true, "okay": true, "go": true, "confirm": true, "confirmed": true,
+		"correct": true, "affirmative": true, "absolutely": true, "definitely": true,
+		"please": true, "right": true,
+	}
+	negativeAnswers = map[string]bool{
+		"no": true, "nope": true, "nah": true, "don't": true, "dont": true,
+		"cancel": true, "stop": true, "wait": true, "negative": true, "never": true,
+	}
+)
+
+// toolFiller speaks at most one filler for a batch of tool calls, so that
+// parallel slow tools do not talk over each other.
+type toolFiller struct {
+	once    sync.Once
+	pending sync.WaitGroup
+	mu      sync.Mutex
+	speech  *speechHandle
+}
+
+// schedule speaks the tool's filler if the call is still running after the
+// filler delay. The returned function marks the call as finished.
+func (f *toolFiller) schedule(ctx context.Context, a *Agent, tool Tool, call llm.ToolCall) (stop func()) {
+	delay := tool.FillerDelay
+	if delay <= 0 {
+		delay = defaultFillerDelay
+	}
+
+	finished := make(chan struct{})
+	f.pending.Add(1)
+	go func() {
+		defer f.pending.Done()
+		select {
+		case <-time.After(delay):
+		case <-finished:
+			return
+		case <-ctx.Done():
+			return
+		}
+
+		text := tool.Filler
+		if tool.FillerFunc != nil {
+			text = tool.FillerFunc(ctx, call.Arguments)
+		}
+		if text == "" {
+			return
+		}
+		f.once.Do(func() {
+			log.Printf("💬 Tool %s is taking a while, speaking filler", call.Name)
+			speech, err := a.speakInterim(ctx, text, StateThinking)
+			if err != nil {
+				log.Printf("⚠️ Failed to speak tool filler: %v", err)
+				return
+			}
+			f.mu.Lock()
+			f.speech = speech
+			f.mu.Unlock()
+		})
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(finished) }) }
+}
+
+// wait blocks until the filler, if one was spoken, has finished playing so that
+// it does not overlap the reply.
+func (f *toolFiller) wait(ctx context.Context) {
+	f.pending.Wait()
+
+	f.mu.Lock()
+	speech := f.speech
+	f.mu.Unlock()
+	if speech == nil {
+		return
+	}
+	select {
+	case <-speech.done:
+	case <-ctx.Done():
+	}
+}
+
+// confirmAndExecuteTool asks the user to confirm the call if the tool requires
+// it, then executes the tool while its filler is scheduled.
+func (a *Agent) confirmAndExecuteTool(ctx context.Context, call llm.ToolCall, filler *toolFiller) (string, error) {
+	tool, exists := a.currentTool(call.Name)
+	if !exists {
+		return a.executeTool(ctx, call)
+	}
+
+	if tool.RequireConfirmation {
+		answer, confirmed, err := a.confirmToolCall(ctx, tool, call)
+		if err != nil {
+			return "", fmt.Errorf("confirmation failed: %w", err)
+		}
+		if !confirmed {
+			log.Printf("🚫 User did not confirm tool %s: %q", call.Name, answer)
+			return fmt.Sprintf("The user did not confirm running %s. They said: %q", call.Name, answer), nil
+		}
+		log.Printf("👍 User confirmed tool %s", call.Name)
+	}
+
+	if tool.Filler != "" || tool.FillerFunc != nil {
+		stop := filler.schedule(ctx, a, tool, call)
+		defer stop()
+	}
+	return a.executeTool(ctx, call)
+}
+
+// confirmToolCall speaks the tool's confirmation prompt and waits for the
+// user's next final transcript. It returns the answer and whether it was a yes.
+func (a *Agent) confirmToolCall(ctx context.Context, tool Tool, call llm.ToolCall) (string, bool, error) {
+	// Parallel calls are confirmed one at a time
+	a.confirmMu.Lock()
+	defer a.confirmMu.Unlock()
+
+	prompt := tool.ConfirmationPrompt
+	if prompt == "" {
+		prompt = fmt.Sprintf("Do you want me to go ahead with %s?", strings.ReplaceAll(call.Name, "_", " "))
+	}
+
+	answers := make(chan string, 1)
+	a.confirmStateMu.Lock()
+	a.confirmation = answers
+	a.confirmStateMu.Unlock()
+	defer func() {
+		a.confirmStateMu.Lock()
+		if a.confirmation == answers {
+			a.confirmation = nil
+		}
+		a.confirmStateMu.Unlock()
+	}()
+
+	log.Printf("❓ Asking user to confirm tool %s", call.Name)
+
+	// The agent goes idle after the prompt so that it listens for the answer
+	speech, err := a.speakInterim(ctx, prompt, StateIdle)
+	if err != nil {
+		return "", false, err
+	}
+	select {
+	case <-speech.done:
+	case answer := <-answers:
+		// The user answered over the prompt
+		return answer, parseConfirmation(answer), nil
+	case <-ctx.Done():
+		return "", false, ctx.Err()
+	}
+
+	select {
+	case answer := <-answers:
+		return answer, parseConfirmation(answer), nil
+	case <-time.After(confirmationTimeout):
+		if a.GetState() == StateIdle {
+			a.setState(StateThinking)
+		}
+		return "", false, fmt.Errorf("no answer within %v", confirmationTimeout)
+	case <-ctx.Done():
+		return "", false, ctx.Err()
+	}
+}
+
+// deliverConfirmation hands a final transcript to a pending confirmation. It
+// reports whether the transcript was consumed.
+func (a *Agent) deliverConfirmation(text string) bool {
+	a.confirmStateMu.Lock()
+	defer a.confirmStateMu.Unlock()
+
+	if a.confirmation == nil {
+		return false
+	}
+	select {
+	case a.confirmation <- text:
+	default:
+		// Already answered
+	}
+	a.confirmation = nil
+	return true
+}
+
+// awaitingConfirmation reports whether a tool call is waiting for the user to confirm it.
+func (a *Agent) awaitingConfirmation() bool {
+	a.confirmStateMu.Lock()
+	defer a.confirmStateMu.Unlock()
+	return a.confirmation != nil
+}
+
+// parseConfirmation reports whether an answer to a confirmation prompt is a yes.
+func parseConfirmation(answer string) bool {
+	words := strings.FieldsFunc(strings.ToLower(answer), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\'' && r != '’'
+	})
+	for i, word := range words {
+		words[i] = strings.ReplaceAll(word, "’", "'")
+		if negativeAnswers[words[i]] {
+			return false
+		}
+	}
+	for _, word := range words {
+		if affirmativeAnswers[word] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/stt"
+)
+
+func TestAgent_ToolFiller(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	synthesizer := &recordingTTS{}
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.TTS = synthesizer
+		cfg.Tools = []Tool{
+			{
+				Name:        "slow_lookup",
+				Filler:      "Let me check that for you.",
+				FillerDelay: 10 * time.Millisecond,
+				Handler: func(ctx context.Context, args string) (string, error) {
+					time.Sleep(100 * time.Millisecond)
+					return "found", nil
+				},
+			},
+			{
+				Name: "other_slow_lookup",
+				FillerFunc: func(ctx context.Context, args string) string {
+					return "Still working on it."
+				},
+				FillerDelay: 10 * time.Millisecond,
+				Handler: func(ctx context.Context, args string) (string, error) {
+					time.Sleep(100 * time.Millisecond)
+					return "found", nil
+				},
+			},
+			{
+				Name:        "fast_lookup",
+				Filler:      "This is never said.",
+				FillerDelay: time.Second,
+				Handler: func(ctx context.Context, args string) (string, error) {
+					return "found", nil
+				},
+			},
+		}
+	})
+
+	agent.setState(StateThinking)
+	agent.handleToolCalls(ctx, "", []llm.ToolCall{
+		{Name: "slow_lookup", Arguments: "{}"},
+		{Name: "other_slow_lookup", Arguments: "{}"},
+		{Name: "fast_lookup", Arguments: "{}"},
+	})
+
+	// Only one filler is spoken for the batch and it has finished playing
+	got := synthesizer.requests()
+	if len(got) != 1 || (got[0] != "Let me check that for you." && got[0] != "Still working on it.") {
+		t.Errorf("expected a single filler, got %q", got)
+	}
+	if agent.GetState() != StateThinking {
+		t.Errorf("expected agent to return to Thinking after the filler, got %v", agent.GetState())
+	}
+	for _, msg := range agent.chatCtx.History() {
+		if strings.Contains(msg.Content, "check that") || strings.Contains(msg.Content, "Still working") {
+			t.Errorf("filler should not be stored in the chat context: %+v", msg)
+		}
+	}
+}
+
+func TestAgent_ToolConfirmation(t *testing.T) {
+	tests := []struct {
+		answer   string
+		executed bool
+	}{
+		{"Yes, go ahead.", true},
+		{"No, don't do that.", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.answer, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			var executed atomic.Bool
+			synthesizer := &recordingTTS{}
+			agent := newTestAgent(t, func(cfg *Config) {
+				cfg.TTS = synthesizer
+				cfg.Tools = []Tool{{
+					Name:                "delete_account",
+					RequireConfirmation: true,
+					ConfirmationPrompt:  "Are you sure you want to delete your account?",
+					Handler: func(ctx context.Context, args string) (string, error) {
+						executed.Store(true)
+						return "deleted", nil
+					},
+				}}
+			})
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				agent.handleToolCalls(ctx, "", []llm.ToolCall{{ID: "call_1", Name: "delete_account", Arguments: "{}"}})
+			}()
+
+			// The prompt is spoken and the agent goes idle to listen for the answer
+			waitForState(t, ctx, agent, StateIdle)
+			for !agent.awaitingConfirmation() {
+				time.Sleep(5 * time.Millisecond)
+			}
+			if got := synthesizer.requests(); len(got) != 1 || got[0] != "Are you sure you want to delete your account?" {
+				t.Fatalf("expected the confirmation prompt, got %q", got)
+			}
+			if executed.Load() {
+				t.Fatal("tool should not run before it is confirmed")
+			}
+
+			// The answer arrives as the final transcript of the user's turn
+			agent.setState(StateThinking)
+			if err := agent.handleSTTEvent(ctx, stt.SpeechEvent{Type: stt.SpeechEventFinal, Text: tt.answer, IsFinal: true}); err != nil {
+				t.Fatalf("handleSTTEvent failed: %v", err)
+			}
+			<-done
+
+			if executed.Load() != tt.executed {
+				t.Errorf("expected executed=%v", tt.executed)
+			}
+			history := agent.chatCtx.History()
+			if len(history) != 2 || history[1].Role != llm.RoleTool {
+				t.Fatalf("expected only the tool call and its result in the history, got %+v", history)
+			}
+			if !tt.executed && !strings.Contains(history[1].Content, "did not confirm") {
+				t.Errorf("expected the result to report the refusal, got %q", history[1].Content)
+			}
+		})
+	}
+}
+
+func TestParseConfirmation(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"yes", true},
+		{"Yeah, go ahead", true},
+		{"OK.", true},
+		{"sure thing", true},
+		{"no", false},
+		{"please don't", false},
+		{"Don’t do it", false},
+		{"yes, wait, no", false},
+		{"what was that?", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := parseConfirmation(tt.answer); got != tt.want {
+			t.Errorf("parseConfirmation(%q) = %v, want %v", tt.answer, got, tt.want)
+		}
+	}
+}
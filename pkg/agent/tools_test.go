@@ -0,0 +1,127 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm/fake"
+)
+
+func TestAgent_ParallelToolCalls(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	slowTool := func(name string, delay time.Duration) Tool {
+		return Tool{
+			Name: name,
+			Handler: func(ctx context.Context, args string) (string, error) {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					m := maxRunning.Load()
+					if n <= m || maxRunning.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(delay)
+				return name + " done", nil
+			},
+		}
+	}
+
+	// The first call finishes last, its result must still come first
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.Tools = []Tool{slowTool("first", 60*time.Millisecond), slowTool("second", 10*time.Millisecond)}
+	})
+
+	agent.handleToolCalls(context.Background(), "", []llm.ToolCall{
+		{ID: "call_a", Name: "first", Arguments: "{}"},
+		{Name: "second", Arguments: "{}"},
+	})
+
+	if maxRunning.Load() != 2 {
+		t.Errorf("expected tools to run concurrently, max concurrency was %d", maxRunning.Load())
+	}
+
+	history := agent.chatCtx.History()
+	if len(history) != 3 {
+		t.Fatalf("expected assistant message and 2 tool results, got %+v", history)
+	}
+	assistant := history[0]
+	if assistant.Role != llm.RoleAssistant || len(assistant.ToolCalls) != 2 {
+		t.Fatalf("expected assistant message with both tool calls, got %+v", assistant)
+	}
+	if assistant.ToolCalls[1].ID == "" {
+		t.Error("expected a generated ID for the call without one")
+	}
+	for i, want := range []string{"first done", "second done"} {
+		result := history[i+1]
+		if result.Role != llm.RoleTool || result.Content != want || result.ToolCallID != assistant.ToolCalls[i].ID {
+			t.Errorf("result %d: expected %q for call %s, got %+v", i, want, assistant.ToolCalls[i].ID, result)
+		}
+	}
+}
+
+func TestAgent_ToolTimeout(t *testing.T) {
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.Tools = []Tool{{
+			Name:    "stuck",
+			Timeout: 20 * time.Millisecond,
+			Handler: func(ctx context.Context, args string) (string, error) {
+				// Ignores ctx, the agent must not wait for it
+				time.Sleep(time.Second)
+				return "too late", nil
+			},
+		}}
+	})
+
+	start := time.Now()
+	agent.handleToolCalls(context.Background(), "", []llm.ToolCall{{ID: "call_a", Name: "stuck", Arguments: "{}"}})
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the tool to time out, took %v", elapsed)
+	}
+
+	records := agent.chatCtx.ToolCalls()
+	if len(records) != 1 || !strings.Contains(records[0].Error, "timed out") {
+		t.Errorf("expected a timeout error to be recorded, got %+v", records)
+	}
+}
+
+// TestAgent_MultipleToolCallsPerTurn verifies that parallel calls requested by
+// the LLM are all executed before the final reply.
+func TestAgent_MultipleToolCallsPerTurn(t *testing.T) {
+	var calls atomic.Int32
+	tool := func(name string) Tool {
+		return Tool{Name: name, Handler: func(ctx context.Context, args string) (string, error) {
+			calls.Add(1)
+			return "ok", nil
+		}}
+	}
+	agent := newTestAgent(t, func(cfg *Config) {
+		cfg.Tools = []Tool{tool("weather"), tool("time")}
+	})
+
+	// The fake LLM calls every function when the user mentions functions,
+	// until the tool call limit forces a final reply
+	model := &recordingLLM{LLM: fake.NewFakeLLM("Done.")}
+	agent.llm = model
+	agent.chatCtx.Append(llm.Message{Role: llm.RoleUser, Content: "call both functions"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := agent.generateLLMReply(ctx, ""); err != nil {
+		t.Fatalf("generateLLMReply failed: %v", err)
+	}
+
+	if got := calls.Load(); got != 2*maxToolCalls {
+		t.Errorf("expected both tools to run on every iteration, got %d calls", got)
+	}
+	model.mu.Lock()
+	defer model.mu.Unlock()
+	last := model.requests[len(model.requests)-1]
+	if last[len(last)-1].Role != llm.RoleTool {
+		t.Errorf("expected the final request to end with a tool result, got %+v", last[len(last)-1])
+	}
+}
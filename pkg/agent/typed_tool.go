@@ -0,0 +1,291 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// NewTool creates a tool whose JSON schema is derived from the Args struct, so
+// the schema the LLM sees always matches the type the handler receives.
+// Arguments are validated against the schema before the handler is called and
+// the result is marshaled to JSON, except for string results which are passed
+// through as is.
+//
+// Fields use their json tag names and are required unless tagged omitempty or
+// declared as pointers. A description tag documents a field for the LLM and an
+// enum tag lists its allowed values:
+//
+//	type WeatherArgs struct {
+//		City string `json:"city" description:"City name"`
+//		Unit string `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+//	}
+//
+// NewTool panics if Args is not a struct or contains types that cannot be
+// expressed in JSON, since that is a programming error.
+func NewTool[Args, Result any](name, description string, handler func(context.Context, Args) (Result, error)) Tool {
+	schema, err := schemaFor(reflect.TypeFor[Args](), nil)
+	if err != nil {
+		panic(fmt.Sprintf("agent: invalid arguments for tool %s: %v", name, err))
+	}
+	if schema["type"] != "object" {
+		panic(fmt.Sprintf("agent: arguments for tool %s must be a struct", name))
+	}
+
+	return Tool{
+		Name:        name,
+		Description: description,
+		Schema:      schema,
+		Handler: func(ctx context.Context, raw string) (string, error) {
+			var value any
+			if err := json.Unmarshal([]byte(raw), &value); err != nil {
+				return "", fmt.Errorf("invalid function arguments JSON: %w", err)
+			}
+			if err := validateSchema(schema, value, ""); err != nil {
+				return "", fmt.Errorf("invalid arguments: %w", err)
+			}
+
+			var args Args
+			if err := json.Unmarshal([]byte(raw), &args); err != nil {
+				return "", fmt.Errorf("invalid arguments: %w", err)
+			}
+
+			result, err := handler(ctx, args)
+			if err != nil {
+				return "", err
+			}
+			if s, ok := any(result).(string); ok {
+				return s, nil
+			}
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal result: %w", err)
+			}
+			return string(encoded), nil
+		},
+	}
+}
+
+// schemaFor derives a JSON schema from a Go type. visiting holds the structs
+// being expanded, recursive types are rejected.
+func schemaFor(t reflect.Type, visiting []reflect.Type) (map[string]any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := schemaFor(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map key type %s is not a string", t.Key())
+		}
+		values, err := schemaFor(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if slices.Contains(visiting, t) {
+			return nil, fmt.Errorf("recursive type %s", t)
+		}
+		return structSchema(t, append(visiting, t))
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// structSchema derives an object schema from a struct's exported fields.
+// Embedded structs without a json name are flattened, as encoding/json does.
+func structSchema(t reflect.Type, visiting []reflect.Type) (map[string]any, error) {
+	properties := map[string]any{}
+	required := []string{}
+
+	var addFields func(t reflect.Type) error
+	addFields = func(t reflect.Type) error {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+
+			if field.Anonymous && name == "" {
+				embedded := field.Type
+				if embedded.Kind() == reflect.Pointer {
+					embedded = embedded.Elem()
+				}
+				if embedded.Kind() == reflect.Struct {
+					if err := addFields(embedded); err != nil {
+						return err
+					}
+					continue
+				}
+				if !field.IsExported() {
+					continue
+				}
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			prop, err := schemaFor(field.Type, visiting)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			if description := field.Tag.Get("description"); description != "" {
+				prop["description"] = description
+			}
+			if enum := field.Tag.Get("enum"); enum != "" {
+				prop["enum"] = strings.Split(enum, ",")
+			}
+			properties[name] = prop
+
+			optional := slices.Contains(strings.Split(opts, ","), "omitempty") || field.Type.Kind() == reflect.Pointer
+			if !optional {
+				required = append(required, name)
+			}
+		}
+		return nil
+	}
+	if err := addFields(t); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}, nil
+}
+
+// validateSchema checks a decoded JSON value against a schema produced by
+// schemaFor. path names the value in error messages.
+func validateSchema(schema map[string]any, value any, path string) error {
+	name := path
+	if name == "" {
+		name = "arguments"
+	}
+
+	if enum, ok := schema["enum"].([]string); ok {
+		s, isString := value.(string)
+		if !isString || !slices.Contains(enum, s) {
+			return fmt.Errorf("%s must be one of %s", name, strings.Join(enum, ", "))
+		}
+	}
+
+	switch schema["type"] {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s must be a string", name)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be a boolean", name)
+		}
+	case "integer":
+		n, ok := value.(float64)
+		if !ok || n != float64(int64(n)) {
+			return fmt.Errorf("%s must be an integer", name)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s must be a number", name)
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s must be an array", name)
+		}
+		itemSchema, _ := schema["items"].(map[string]any)
+		for i, item := range items {
+			if err := validateSchema(itemSchema, item, fmt.Sprintf("%s[%d]", name, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s must be an object", name)
+		}
+		return validateObject(schema, object, path)
+	}
+	return nil
+}
+
+// validateObject checks required, known and additional properties of an object.
+func validateObject(schema map[string]any, object map[string]any, path string) error {
+	prefix := ""
+	if path != "" {
+		prefix = path + "."
+	}
+
+	if required, ok := schema["required"].([]string); ok {
+		for _, key := range required {
+			if _, present := object[key]; !present {
+				return fmt.Errorf("%s%s is required", prefix, key)
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	additional := schema["additionalProperties"]
+
+	// Check keys in a stable order so errors are deterministic
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		value := object[key]
+		if prop, ok := properties[key].(map[string]any); ok {
+			if value == nil && !slices.Contains(schemaRequired(schema), key) {
+				continue // null is accepted for optional fields
+			}
+			if err := validateSchema(prop, value, prefix+key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch additional := additional.(type) {
+		case bool:
+			if !additional {
+				return fmt.Errorf("unknown field %s%s", prefix, key)
+			}
+		case map[string]any:
+			if err := validateSchema(additional, value, prefix+key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaRequired returns the required property names of an object schema.
+func schemaRequired(schema map[string]any) []string {
+	required, _ := schema["required"].([]string)
+	return required
+}
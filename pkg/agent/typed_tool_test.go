@@ -0,0 +1,147 @@
+package agent
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type weatherArgs struct {
+	City    string   `json:"city" description:"City name"`
+	Unit    string   `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+	Days    int      `json:"days"`
+	Tags    []string `json:"tags,omitempty"`
+	Verbose *bool    `json:"verbose"`
+	secret  string
+}
+
+type weatherResult struct {
+	Temperature float64 `json:"temperature"`
+	Summary     string  `json:"summary"`
+}
+
+func TestNewTool_Schema(t *testing.T) {
+	tool := NewTool("get_weather", "Get the weather", func(ctx context.Context, args weatherArgs) (weatherResult, error) {
+		return weatherResult{}, nil
+	})
+
+	if tool.Name != "get_weather" || tool.Description != "Get the weather" {
+		t.Errorf("unexpected tool metadata: %s %q", tool.Name, tool.Description)
+	}
+
+	properties := tool.Schema["properties"].(map[string]any)
+	if len(properties) != 5 {
+		t.Errorf("expected 5 properties without the unexported field, got %v", properties)
+	}
+	city := properties["city"].(map[string]any)
+	if city["type"] != "string" || city["description"] != "City name" {
+		t.Errorf("unexpected city schema: %v", city)
+	}
+	unit := properties["unit"].(map[string]any)
+	if !reflect.DeepEqual(unit["enum"], []string{"celsius", "fahrenheit"}) {
+		t.Errorf("unexpected unit enum: %v", unit["enum"])
+	}
+	if properties["days"].(map[string]any)["type"] != "integer" {
+		t.Errorf("expected integer days, got %v", properties["days"])
+	}
+	tags := properties["tags"].(map[string]any)
+	if tags["type"] != "array" || tags["items"].(map[string]any)["type"] != "string" {
+		t.Errorf("unexpected tags schema: %v", tags)
+	}
+
+	required := tool.Schema["required"].([]string)
+	if !reflect.DeepEqual(required, []string{"city", "days"}) {
+		t.Errorf("expected city and days to be required, got %v", required)
+	}
+}
+
+func TestNewTool_Handler(t *testing.T) {
+	var received weatherArgs
+	tool := NewTool("get_weather", "Get the weather", func(ctx context.Context, args weatherArgs) (weatherResult, error) {
+		received = args
+		return weatherResult{Temperature: 21.5, Summary: "sunny"}, nil
+	})
+
+	result, err := tool.Handler(context.Background(), `{"city": "Paris", "days": 2, "unit": "celsius"}`)
+	if err != nil {
+		t.Fatalf("handler failed: %v", err)
+	}
+	if received.City != "Paris" || received.Days != 2 || received.Unit != "celsius" {
+		t.Errorf("unexpected arguments: %+v", received)
+	}
+	if result != `{"temperature":21.5,"summary":"sunny"}` {
+		t.Errorf("unexpected result: %s", result)
+	}
+
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"missing required field", `{"days": 2}`, "city is required"},
+		{"wrong type", `{"city": "Paris", "days": "two"}`, "days must be an integer"},
+		{"fractional integer", `{"city": "Paris", "days": 1.5}`, "days must be an integer"},
+		{"invalid enum value", `{"city": "Paris", "days": 2, "unit": "kelvin"}`, "unit must be one of celsius, fahrenheit"},
+		{"unknown field", `{"city": "Paris", "days": 2, "country": "FR"}`, "unknown field country"},
+		{"array item type", `{"city": "Paris", "days": 2, "tags": ["a", 1]}`, "tags[1] must be a string"},
+		{"not an object", `[]`, "arguments must be an object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tool.Handler(context.Background(), tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// Optional pointer fields accept null
+	if _, err := tool.Handler(context.Background(), `{"city": "Paris", "days": 1, "verbose": null}`); err != nil {
+		t.Errorf("expected null to be accepted for an optional field, got %v", err)
+	}
+}
+
+func TestNewTool_StringResult(t *testing.T) {
+	tool := NewTool("echo", "Echo the text", func(ctx context.Context, args struct {
+		Text string `json:"text"`
+	}) (string, error) {
+		return args.Text, nil
+	})
+
+	result, err := tool.Handler(context.Background(), `{"text": "hello"}`)
+	if err != nil || result != "hello" {
+		t.Errorf("expected string result to pass through, got %q, %v", result, err)
+	}
+}
+
+func TestNewTool_InvalidArgs(t *testing.T) {
+	type node struct {
+		Children []node `json:"children"`
+	}
+
+	tests := []struct {
+		name  string
+		build func()
+	}{
+		{"not a struct", func() {
+			NewTool("bad", "", func(ctx context.Context, args string) (string, error) { return "", nil })
+		}},
+		{"unsupported field", func() {
+			NewTool("bad", "", func(ctx context.Context, args struct{ C chan int }) (string, error) { return "", nil })
+		}},
+		{"recursive type", func() {
+			NewTool("bad", "", func(ctx context.Context, args node) (string, error) { return "", nil })
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected NewTool to panic")
+				}
+			}()
+			tt.build()
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrCircuitOpen is returned while a circuit breaker rejects calls. It is
+// always also classified as ErrRecoverable, so that a FallbackAdapter moves on
+// to another provider.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// BreakerStates publishes the state of every circuit breaker, keyed by name,
+// as the "ai_circuit_breakers" expvar.
+var BreakerStates = expvar.NewMap("ai_circuit_breakers")
+
+// breakerCount numbers breakers created without a name.
+var breakerCount atomic.Int64
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets calls through and counts their failures
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects calls until the cool-down has passed
+	BreakerOpen
+	// BreakerHalfOpen lets a few trial calls through to decide whether to close
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("unknown(%d)", s)
+	}
+}
+
+// BreakerConfig configures a circuit breaker.
+type BreakerConfig struct {
+	// Name identifies the provider in errors and metrics, and should be unique
+	// (optional, defaults to a unique "provider-N")
+	Name string
+
+	// FailureRatio is the share of failed calls within Window that opens the
+	// breaker (optional, defaults to 0.5)
+	FailureRatio float64
+
+	// MinRequests is how many calls Window must contain before the failure
+	// ratio is considered (optional, defaults to 5)
+	MinRequests int
+
+	// Window is the period over which calls are counted (optional, defaults to 60s)
+	Window time.Duration
+
+	// CoolDown is how long the breaker stays open before trial calls are let
+	// through (optional, defaults to 30s)
+	CoolDown time.Duration
+
+	// HalfOpenRequests is how many trial calls may be in flight while the
+	// breaker is half-open (optional, defaults to 1)
+	HalfOpenRequests int
+
+	// OnStateChange is called after every state change, outside the
+	// breaker's lock (optional)
+	OnStateChange func(name string, from, to BreakerState)
+}
+
+// DefaultBreakerConfig provides sensible defaults for circuit breakers
+var DefaultBreakerConfig = BreakerConfig{
+	FailureRatio:     0.5,
+	MinRequests:      5,
+	Window:           60 * time.Second,
+	CoolDown:         30 * time.Second,
+	HalfOpenRequests: 1,
+}
+
+// CircuitBreaker stops calling a provider that keeps failing. While closed it
+// counts calls and failures; once the failure ratio reaches the threshold it
+// opens and rejects calls with ErrCircuitOpen for the cool-down. It then lets
+// trial calls through while half-open, closing again if they succeed and
+// reopening if one fails.
+//
+// Recoverable and unclassified errors count as failures. Fatal errors, such as
+// an invalid request, and cancellation do not, since they say nothing about
+// the provider's health.
+type CircuitBreaker struct {
+	cfg BreakerConfig
+	now func() time.Time
+
+	mu          sync.Mutex
+	state       BreakerState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	trials      int // trial calls in flight while half-open
+}
+
+// NewCircuitBreaker creates a closed circuit breaker.
+func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
+	if cfg.Name == "" {
+		cfg.Name = fmt.Sprintf("provider-%d", breakerCount.Add(1))
+	}
+	if cfg.FailureRatio <= 0 {
+		cfg.FailureRatio = DefaultBreakerConfig.FailureRatio
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = DefaultBreakerConfig.MinRequests
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultBreakerConfig.Window
+	}
+	if cfg.CoolDown <= 0 {
+		cfg.CoolDown = DefaultBreakerConfig.CoolDown
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		cfg.HalfOpenRequests = DefaultBreakerConfig.HalfOpenRequests
+	}
+
+	b := &CircuitBreaker{cfg: cfg, now: time.Now}
+	b.windowStart = b.now()
+	b.publish()
+	return b
+}
+
+// State returns the breaker's current state.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cfg.CoolDown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Allow reports whether a call may go ahead. Every allowed call must be
+// followed by Record with its outcome.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.unlock(b.state)
+
+	if b.state == BreakerOpen {
+		if b.now().Sub(b.openedAt) < b.cfg.CoolDown {
+			return fmt.Errorf("%s: %w: %w", b.cfg.Name, ErrCircuitOpen, ErrRecoverable)
+		}
+		b.setState(BreakerHalfOpen)
+	}
+	if b.state == BreakerHalfOpen {
+		if b.trials >= b.cfg.HalfOpenRequests {
+			return fmt.Errorf("%s: %w: %w", b.cfg.Name, ErrCircuitOpen, ErrRecoverable)
+		}
+		b.trials++
+	}
+	return nil
+}
+
+// Record records the outcome of a call that Allow let through.
+func (b *CircuitBreaker) Record(err error) {
+	failed := err != nil && !IsFatal(err) &&
+		!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+
+	b.mu.Lock()
+	defer b.unlock(b.state)
+
+	if b.state == BreakerHalfOpen {
+		b.trials--
+		if failed {
+			b.trip(err)
+		} else if err == nil {
+			b.resetWindow()
+			b.setState(BreakerClosed)
+		}
+		return
+	}
+	if b.state == BreakerOpen {
+		// A call started before the breaker opened
+		return
+	}
+
+	if b.now().Sub(b.windowStart) >= b.cfg.Window {
+		b.resetWindow()
+	}
+	b.requests++
+	if failed {
+		b.failures++
+	}
+	if b.requests >= b.cfg.MinRequests && float64(b.failures)/float64(b.requests) >= b.cfg.FailureRatio {
+		b.trip(err)
+	}
+}
+
+// Do calls fn if the breaker allows it and records the outcome.
+func (b *CircuitBreaker) Do(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.Record(err)
+	return err
+}
+
+// trip opens the breaker. Callers hold mu.
+func (b *CircuitBreaker) trip(err error) {
+	log.Printf("⚠️ Circuit breaker %s opened for %v: %v", b.cfg.Name, b.cfg.CoolDown, err)
+	b.openedAt = b.now()
+	b.trials = 0
+	b.setState(BreakerOpen)
+}
+
+// resetWindow starts a new counting window. Callers hold mu.
+func (b *CircuitBreaker) resetWindow() {
+	b.windowStart = b.now()
+	b.requests = 0
+	b.failures = 0
+}
+
+// setState changes the state and publishes it. Callers hold mu.
+func (b *CircuitBreaker) setState(state BreakerState) {
+	if b.state != state {
+		b.state = state
+		b.publish()
+	}
+}
+
+// unlock releases mu and calls OnStateChange if the state changed from the
+// one the caller started with.
+func (b *CircuitBreaker) unlock(from BreakerState) {
+	to := b.state
+	b.mu.Unlock()
+	if to != from && b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(b.cfg.Name, from, to)
+	}
+}
+
+// publish records the state in BreakerStates.
+func (b *CircuitBreaker) publish() {
+	state := new(expvar.String)
+	state.Set(b.state.String())
+	BreakerStates.Set(b.cfg.Name, state)
+}
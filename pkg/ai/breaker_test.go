@@ -0,0 +1,127 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestBreaker returns a breaker whose clock is advanced by the returned func.
+func newTestBreaker(cfg BreakerConfig) (*CircuitBreaker, func(time.Duration)) {
+	b := NewCircuitBreaker(cfg)
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+	b.windowStart = now
+	return b, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestCircuitBreaker_OpensOnFailureRatio(t *testing.T) {
+	b, _ := newTestBreaker(BreakerConfig{Name: "test-ratio", MinRequests: 4, FailureRatio: 0.5})
+	failure := NewRecoverableError(errors.New("503"), "service unavailable")
+
+	b.Record(nil)
+	b.Record(failure)
+	b.Record(nil)
+	if b.State() != BreakerClosed {
+		t.Fatalf("expected the breaker to stay closed below MinRequests, got %v", b.State())
+	}
+	b.Record(failure)
+	if b.State() != BreakerOpen {
+		t.Fatalf("expected the breaker to open at the failure ratio, got %v", b.State())
+	}
+
+	err := b.Allow()
+	if !errors.Is(err, ErrCircuitOpen) || !IsRecoverable(err) {
+		t.Errorf("expected a recoverable ErrCircuitOpen, got %v", err)
+	}
+	if got := BreakerStates.Get("test-ratio").String(); got != `"open"` {
+		t.Errorf("expected the open state to be published, got %s", got)
+	}
+}
+
+func TestCircuitBreaker_IgnoresFatalAndCancelled(t *testing.T) {
+	b, _ := newTestBreaker(BreakerConfig{Name: "test-ignore", MinRequests: 2})
+
+	for i := 0; i < 5; i++ {
+		b.Record(NewFatalError(errors.New("400"), "invalid request"))
+		b.Record(context.Canceled)
+	}
+	if b.State() != BreakerClosed {
+		t.Errorf("expected fatal and cancelled calls not to open the breaker, got %v", b.State())
+	}
+}
+
+func TestCircuitBreaker_WindowResets(t *testing.T) {
+	b, advance := newTestBreaker(BreakerConfig{Name: "test-window", MinRequests: 2, Window: time.Minute})
+
+	b.Record(errors.New("timeout"))
+	advance(2 * time.Minute)
+	b.Record(nil)
+	b.Record(nil)
+	if b.State() != BreakerClosed {
+		t.Errorf("expected failures from an earlier window to be forgotten, got %v", b.State())
+	}
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	var changes []string
+	b, advance := newTestBreaker(BreakerConfig{
+		Name:        "test-half-open",
+		MinRequests: 1,
+		CoolDown:    10 * time.Second,
+		OnStateChange: func(name string, from, to BreakerState) {
+			changes = append(changes, from.String()+"->"+to.String())
+		},
+	})
+
+	b.Record(errors.New("timeout"))
+	advance(10 * time.Second)
+	if b.State() != BreakerHalfOpen {
+		t.Fatalf("expected the breaker to be half-open after the cool-down, got %v", b.State())
+	}
+
+	// A single trial call is let through and a failure reopens the breaker
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected a trial call to be allowed, got %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected a second trial call to be rejected, got %v", err)
+	}
+	b.Record(errors.New("timeout"))
+	if b.State() != BreakerOpen {
+		t.Fatalf("expected a failed trial to reopen the breaker, got %v", b.State())
+	}
+
+	// A successful trial closes it
+	advance(10 * time.Second)
+	if err := b.Do(func() error { return nil }); err != nil {
+		t.Fatalf("expected a trial call to succeed, got %v", err)
+	}
+	if b.State() != BreakerClosed {
+		t.Errorf("expected a successful trial to close the breaker, got %v", b.State())
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(changes) != len(want) {
+		t.Fatalf("expected state changes %v, got %v", want, changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("state change %d: expected %s, got %s", i, want[i], changes[i])
+		}
+	}
+}
+
+func TestCircuitBreaker_DefaultNamesAreUnique(t *testing.T) {
+	first, _ := newTestBreaker(BreakerConfig{MinRequests: 1})
+	second, _ := newTestBreaker(BreakerConfig{MinRequests: 1})
+	if first.cfg.Name == second.cfg.Name {
+		t.Fatalf("expected unnamed breakers to get distinct names, both got %q", first.cfg.Name)
+	}
+
+	first.Record(errors.New("timeout"))
+	if got := BreakerStates.Get(second.cfg.Name).String(); got != `"closed"` {
+		t.Errorf("expected the second breaker's state to be published separately, got %s", got)
+	}
+}
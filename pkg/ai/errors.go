@@ -2,6 +2,8 @@ package ai
 
 import (
 	"errors"
+	"math"
+	"math/rand"
 	"time"
 )
 
@@ -40,6 +42,26 @@ var DefaultRetryConfig = RetryConfig{
 	JitterPercent: 0.1,
 }
 
+// Backoff returns the delay before retry number attempt, starting at 1. The
+// delay grows exponentially from InitialDelay up to MaxDelay and is spread by
+// JitterPercent in either direction.
+func (c RetryConfig) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := float64(c.InitialDelay)
+	if c.BackoffFactor > 0 {
+		delay *= math.Pow(c.BackoffFactor, float64(attempt-1))
+	}
+	if c.MaxDelay > 0 && delay > float64(c.MaxDelay) {
+		delay = float64(c.MaxDelay)
+	}
+	if c.JitterPercent > 0 {
+		delay += (rand.Float64()*2 - 1) * delay * float64(c.JitterPercent)
+	}
+	return time.Duration(delay)
+}
+
 // IsRecoverable checks if an error is recoverable and should be retried
 func IsRecoverable(err error) bool {
 	return errors.Is(err, ErrRecoverable)
@@ -14,6 +14,8 @@ import (
 
 // RetryableSTTClient demonstrates proper error handling and retry logic
 // for AI providers with recoverable and fatal error classification.
+// Production code should use the RetrySTT, RetryTTS, RetryLLM and RetryVAD
+// wrappers and the FallbackAdapter of each provider package instead.
 type RetryableSTTClient struct {
 	provider stt.STT
 	config   ai.RetryConfig
@@ -0,0 +1,174 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// FallbackConfig configures failover between providers.
+type FallbackConfig struct {
+	// MaxFailures is how many consecutive recoverable failures take a provider
+	// out of use (optional, defaults to 3). Fatal failures always do.
+	MaxFailures int
+
+	// HealthCheckInterval is how often a provider out of use is checked
+	// (optional, defaults to 30s)
+	HealthCheckInterval time.Duration
+}
+
+// DefaultFallbackConfig provides sensible defaults for provider failover
+var DefaultFallbackConfig = FallbackConfig{
+	MaxFailures:         3,
+	HealthCheckInterval: 30 * time.Second,
+}
+
+// Failover chooses between providers of the same kind listed in order of
+// preference. Each call goes to the first provider in use and moves on to the
+// next one if it fails. A provider that fails fatally, or MaxFailures times in
+// a row, is taken out of use and health checked in the background until it
+// recovers, so the primary is used again as soon as it is healthy.
+type Failover[T any] struct {
+	providers []T
+	check     func(ctx context.Context, provider T) error
+	cfg       FallbackConfig
+
+	mu       sync.Mutex
+	failures []int
+	down     []bool
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewFailover creates a Failover for providers, which must not be empty. check
+// reports whether a provider out of use has recovered.
+func NewFailover[T any](providers []T, check func(ctx context.Context, provider T) error, cfg FallbackConfig) *Failover[T] {
+	if cfg.MaxFailures <= 0 {
+		cfg.MaxFailures = DefaultFallbackConfig.MaxFailures
+	}
+	if cfg.HealthCheckInterval <= 0 {
+		cfg.HealthCheckInterval = DefaultFallbackConfig.HealthCheckInterval
+	}
+	return &Failover[T]{
+		providers: providers,
+		check:     check,
+		cfg:       cfg,
+		failures:  make([]int, len(providers)),
+		down:      make([]bool, len(providers)),
+		closed:    make(chan struct{}),
+	}
+}
+
+// Primary returns the most preferred provider.
+func (f *Failover[T]) Primary() T {
+	return f.providers[0]
+}
+
+// Active returns the index of the provider calls currently go to.
+func (f *Failover[T]) Active() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, down := range f.down {
+		if !down {
+			return i
+		}
+	}
+	return 0
+}
+
+// Do calls fn with each provider in use, in order, until one succeeds. If
+// every provider is out of use, all of them are tried. It returns the index of
+// the provider that succeeded, or the last error. Errors after ctx was
+// cancelled are returned without trying other providers.
+//
+// Failures are recorded by Do. Since the call fn starts may still fail later,
+// for example while streaming, the caller reports its outcome with Report.
+func (f *Failover[T]) Do(ctx context.Context, fn func(provider T) error) (int, error) {
+	var lastErr error
+	for _, i := range f.candidates() {
+		err := fn(f.providers[i])
+		if err == nil {
+			return i, nil
+		}
+		if ctx.Err() != nil {
+			return i, err
+		}
+		f.Report(i, err)
+		lastErr = err
+	}
+	return -1, lastErr
+}
+
+// candidates returns the indexes of the providers to try, in order.
+func (f *Failover[T]) candidates() []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var up []int
+	for i, down := range f.down {
+		if !down {
+			up = append(up, i)
+		}
+	}
+	if len(up) == 0 {
+		for i := range f.providers {
+			up = append(up, i)
+		}
+	}
+	return up
+}
+
+// Report records the outcome of a call to provider i started with Do.
+// Cancellation is not counted as a failure.
+func (f *Failover[T]) Report(i int, err error) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		f.failures[i] = 0
+		return
+	}
+
+	f.failures[i]++
+	if f.down[i] || (!IsFatal(err) && f.failures[i] < f.cfg.MaxFailures) {
+		return
+	}
+	log.Printf("⚠️ Provider %d taken out of use after %d failures: %v", i, f.failures[i], err)
+	f.down[i] = true
+	go f.healthCheck(i)
+}
+
+// healthCheck checks provider i until it recovers or the Failover is closed.
+func (f *Failover[T]) healthCheck(i int) {
+	ticker := time.NewTicker(f.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-f.closed:
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), f.cfg.HealthCheckInterval)
+		err := f.check(ctx, f.providers[i])
+		cancel()
+		if err == nil {
+			log.Printf("✅ Provider %d is healthy again", i)
+			f.mu.Lock()
+			f.down[i] = false
+			f.failures[i] = 0
+			f.mu.Unlock()
+			return
+		}
+	}
+}
+
+// Close stops health checks.
+func (f *Failover[T]) Close() {
+	f.closeOnce.Do(func() { close(f.closed) })
+}
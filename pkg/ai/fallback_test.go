@@ -0,0 +1,120 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testProvider fails while its fail flag is set.
+type testProvider struct {
+	name string
+	fail atomic.Bool
+}
+
+func (p *testProvider) call() error {
+	if p.fail.Load() {
+		return NewRecoverableError(errors.New("unavailable"), p.name+" unavailable")
+	}
+	return nil
+}
+
+func newTestFailover(providers ...*testProvider) *Failover[*testProvider] {
+	check := func(ctx context.Context, p *testProvider) error { return p.call() }
+	return NewFailover(providers, check, FallbackConfig{MaxFailures: 2, HealthCheckInterval: 5 * time.Millisecond})
+}
+
+// use calls the failover once and returns the name of the provider that answered.
+func use(t *testing.T, f *Failover[*testProvider]) string {
+	t.Helper()
+	var name string
+	_, err := f.Do(context.Background(), func(p *testProvider) error {
+		name = p.name
+		return p.call()
+	})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	return name
+}
+
+func TestFailover_FailsOverAndRecovers(t *testing.T) {
+	primary, secondary := &testProvider{name: "primary"}, &testProvider{name: "secondary"}
+	f := newTestFailover(primary, secondary)
+	defer f.Close()
+
+	if got := use(t, f); got != "primary" {
+		t.Fatalf("expected the primary, got %s", got)
+	}
+
+	// Each call fails over within the call, and the primary is taken out of
+	// use after MaxFailures failures in a row
+	primary.fail.Store(true)
+	for i := 0; i < 2; i++ {
+		if got := use(t, f); got != "secondary" {
+			t.Fatalf("call %d: expected the secondary, got %s", i, got)
+		}
+	}
+	if f.Active() != 1 {
+		t.Fatalf("expected the primary to be out of use, active %d", f.Active())
+	}
+
+	// The health check switches back once the primary recovers
+	primary.fail.Store(false)
+	deadline := time.After(time.Second)
+	for f.Active() != 0 {
+		select {
+		case <-deadline:
+			t.Fatal("primary was not used again after recovering")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	if got := use(t, f); got != "primary" {
+		t.Errorf("expected the recovered primary, got %s", got)
+	}
+}
+
+func TestFailover_FatalTakesOutOfUse(t *testing.T) {
+	primary, secondary := &testProvider{name: "primary"}, &testProvider{name: "secondary"}
+	f := newTestFailover(primary, secondary)
+	defer f.Close()
+
+	f.Report(0, NewFatalError(errors.New("bad key"), "invalid API key"))
+	if f.Active() != 1 {
+		t.Errorf("expected a fatal error to fail over immediately, active %d", f.Active())
+	}
+}
+
+func TestFailover_AllFailing(t *testing.T) {
+	primary, secondary := &testProvider{name: "primary"}, &testProvider{name: "secondary"}
+	primary.fail.Store(true)
+	secondary.fail.Store(true)
+	f := newTestFailover(primary, secondary)
+	defer f.Close()
+
+	var tried []string
+	i, err := f.Do(context.Background(), func(p *testProvider) error {
+		tried = append(tried, p.name)
+		return p.call()
+	})
+	if err == nil || i != -1 {
+		t.Fatalf("expected failure, got provider %d", i)
+	}
+	if len(tried) != 2 {
+		t.Errorf("expected both providers to be tried, got %v", tried)
+	}
+}
+
+func TestFailover_CancellationNotCounted(t *testing.T) {
+	f := newTestFailover(&testProvider{name: "primary"}, &testProvider{name: "secondary"})
+	defer f.Close()
+
+	for i := 0; i < 5; i++ {
+		f.Report(0, context.Canceled)
+	}
+	if f.Active() != 0 {
+		t.Errorf("expected cancellation not to take the primary out of use")
+	}
+}
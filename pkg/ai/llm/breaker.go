@@ -0,0 +1,55 @@
+package llm
+
+import (
+	"context"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+)
+
+// BreakerLLM guards an LLM provider with a circuit breaker. While the breaker
+// is open, requests fail immediately with ai.ErrCircuitOpen instead of waiting
+// on a provider that keeps failing. A streamed response counts as failed if
+// the stream fails to open or delivers an error.
+type BreakerLLM struct {
+	llm     LLM
+	breaker *ai.CircuitBreaker
+}
+
+// NewBreakerLLM wraps an LLM provider in a BreakerLLM.
+func NewBreakerLLM(l LLM, cfg ai.BreakerConfig) *BreakerLLM {
+	return &BreakerLLM{llm: l, breaker: ai.NewCircuitBreaker(cfg)}
+}
+
+// Breaker returns the circuit breaker guarding the provider.
+func (b *BreakerLLM) Breaker() *ai.CircuitBreaker {
+	return b.breaker
+}
+
+// Chat performs a chat completion request if the breaker allows it.
+func (b *BreakerLLM) Chat(ctx context.Context, req ChatRequest) (ChatResponse, error) {
+	var resp ChatResponse
+	err := b.breaker.Do(func() error {
+		var err error
+		resp, err = b.llm.Chat(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// ChatStream streams a chat completion if the breaker allows it.
+func (b *BreakerLLM) ChatStream(ctx context.Context, req ChatRequest) (<-chan ChatChunk, error) {
+	if err := b.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	chunks, err := ChatStream(ctx, b.llm, req)
+	if err != nil {
+		b.breaker.Record(err)
+		return nil, err
+	}
+	return reportChunks(ctx, chunks, b.breaker.Record), nil
+}
+
+// Capabilities returns the wrapped provider's capabilities.
+func (b *BreakerLLM) Capabilities() LLMCapabilities {
+	return b.llm.Capabilities()
+}
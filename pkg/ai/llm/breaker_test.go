@@ -0,0 +1,33 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+)
+
+func TestBreakerLLM_OpensOnStreamErrors(t *testing.T) {
+	provider := &scriptedLLM{name: "primary", stream: recoverable("connection reset")}
+	breaker := NewBreakerLLM(provider, ai.BreakerConfig{Name: "test-llm", MinRequests: 2})
+
+	for i := 0; i < 2; i++ {
+		chunks, err := breaker.ChatStream(context.Background(), ChatRequest{})
+		if err != nil {
+			t.Fatalf("expected stream %d to open, got %v", i, err)
+		}
+		for range chunks {
+		}
+	}
+	if breaker.Breaker().State() != ai.BreakerOpen {
+		t.Fatalf("expected stream errors to open the breaker, got %v", breaker.Breaker().State())
+	}
+
+	if _, err := breaker.Chat(context.Background(), ChatRequest{}); !errors.Is(err, ai.ErrCircuitOpen) {
+		t.Errorf("expected requests to fail fast, got %v", err)
+	}
+	if provider.calls != 2 {
+		t.Errorf("expected the provider not to be called while open, got %d calls", provider.calls)
+	}
+}
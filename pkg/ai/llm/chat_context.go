@@ -0,0 +1,198 @@
+package llm
+
+import (
+	"sync"
+	"time"
+)
+
+// ToolCallRecord records a single tool invocation made on behalf of the LLM.
+type ToolCallRecord struct {
+	ID        string        // Tool call ID assigned by the LLM
+	Name      string        // Function name
+	Arguments string        // JSON-encoded arguments
+	Result    string        // Result returned to the LLM
+	Error     string        // Error message if the call failed
+	Started   time.Time     // When the call started
+	Duration  time.Duration // How long the call took
+}
+
+// TruncationStrategy limits the history sent to the LLM.
+// Implementations receive the full history (without instructions) and return
+// the suffix that should be sent.
+type TruncationStrategy interface {
+	Truncate(messages []Message) []Message
+}
+
+// MaxMessages keeps at most the given number of most recent messages.
+type MaxMessages int
+
+// Truncate implements TruncationStrategy.
+func (n MaxMessages) Truncate(messages []Message) []Message {
+	if n <= 0 || len(messages) <= int(n) {
+		return messages
+	}
+	return messages[len(messages)-int(n):]
+}
+
+// TokenBudget keeps the most recent messages whose estimated token count fits
+// within MaxTokens. Estimate defaults to EstimateTokens.
+type TokenBudget struct {
+	MaxTokens int
+	Estimate  func(Message) int
+}
+
+// Truncate implements TruncationStrategy.
+func (b TokenBudget) Truncate(messages []Message) []Message {
+	if b.MaxTokens <= 0 {
+		return messages
+	}
+	estimate := b.Estimate
+	if estimate == nil {
+		estimate = EstimateTokens
+	}
+
+	total := 0
+	start := len(messages)
+	for i := len(messages) - 1; i >= 0; i-- {
+		total += estimate(messages[i])
+		if total > b.MaxTokens {
+			break
+		}
+		start = i
+	}
+	return messages[start:]
+}
+
+// EstimateTokens roughly estimates the token count of a message using the
+// common heuristic of four characters per token plus a fixed per-message overhead.
+func EstimateTokens(msg Message) int {
+	const perMessageOverhead = 4
+	return perMessageOverhead + (len(msg.Content)+len(msg.Name)+3)/4
+}
+
+// ChatContext holds the conversation state sent to the LLM: the system
+// instructions, the full user/assistant/function history and a record of tool
+// calls. It is safe for concurrent use.
+type ChatContext struct {
+	mu           sync.RWMutex
+	instructions string
+	messages     []Message
+	toolCalls    []ToolCallRecord
+	truncation   TruncationStrategy
+}
+
+// NewChatContext creates a chat context with the given system instructions.
+func NewChatContext(instructions string) *ChatContext {
+	return &ChatContext{instructions: instructions}
+}
+
+// Instructions returns the system instructions.
+func (c *ChatContext) Instructions() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.instructions
+}
+
+// SetInstructions replaces the system instructions.
+func (c *ChatContext) SetInstructions(instructions string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.instructions = instructions
+}
+
+// SetTruncation sets the strategy used by Messages to limit the history.
+// A nil strategy sends the full history.
+func (c *ChatContext) SetTruncation(strategy TruncationStrategy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.truncation = strategy
+}
+
+// Append adds messages to the history.
+func (c *ChatContext) Append(messages ...Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, messages...)
+}
+
+// ReplaceHistory discards the message history and replaces it with messages.
+// Instructions, truncation and tool call records are kept.
+func (c *ChatContext) ReplaceHistory(messages ...Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append([]Message(nil), messages...)
+}
+
+// ReplacePrefix replaces the first n messages of the history with messages,
+// keeping the messages added after them. It lets a snapshot taken with
+// History be replaced, such as by its summary, without losing the messages
+// appended in the meantime.
+func (c *ChatContext) ReplacePrefix(n int, messages ...Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n = min(max(n, 0), len(c.messages))
+	c.messages = append(append([]Message(nil), messages...), c.messages[n:]...)
+}
+
+// AddToolCall records a tool invocation.
+func (c *ChatContext) AddToolCall(record ToolCallRecord) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.toolCalls = append(c.toolCalls, record)
+}
+
+// ToolCalls returns a copy of the recorded tool invocations.
+func (c *ChatContext) ToolCalls() []ToolCallRecord {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]ToolCallRecord(nil), c.toolCalls...)
+}
+
+// History returns a copy of the full, untruncated message history without instructions.
+func (c *ChatContext) History() []Message {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]Message(nil), c.messages...)
+}
+
+// Len returns the number of messages in the history.
+func (c *ChatContext) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.messages)
+}
+
+// Messages returns the messages to send to the LLM: the instructions as a
+// system message followed by the history after truncation. Function and tool
+// results left at the start of the truncated history without their originating
+// call are dropped.
+func (c *ChatContext) Messages() []Message {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	history := c.messages
+	if c.truncation != nil {
+		history = c.truncation.Truncate(history)
+	}
+	for len(history) > 0 && (history[0].Role == RoleFunction || history[0].Role == RoleTool) {
+		history = history[1:]
+	}
+
+	messages := make([]Message, 0, len(history)+1)
+	if c.instructions != "" {
+		messages = append(messages, Message{Role: RoleSystem, Content: c.instructions})
+	}
+	return append(messages, history...)
+}
+
+// Copy returns an independent copy of the chat context.
+func (c *ChatContext) Copy() *ChatContext {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return &ChatContext{
+		instructions: c.instructions,
+		messages:     append([]Message(nil), c.messages...),
+		toolCalls:    append([]ToolCallRecord(nil), c.toolCalls...),
+		truncation:   c.truncation,
+	}
+}
@@ -0,0 +1,128 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChatContext_Messages(t *testing.T) {
+	chatCtx := NewChatContext("You are a helpful assistant.")
+	chatCtx.Append(
+		Message{Role: RoleUser, Content: "Hello"},
+		Message{Role: RoleAssistant, Content: "Hi there!"},
+	)
+
+	messages := chatCtx.Messages()
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(messages))
+	}
+	if messages[0].Role != RoleSystem || messages[0].Content != "You are a helpful assistant." {
+		t.Errorf("expected instructions as system message, got %+v", messages[0])
+	}
+	if messages[2].Content != "Hi there!" {
+		t.Errorf("expected history in order, got %+v", messages[2])
+	}
+
+	// History excludes instructions and is a copy
+	history := chatCtx.History()
+	history[0].Content = "modified"
+	if chatCtx.History()[0].Content != "Hello" {
+		t.Error("History() should return a copy")
+	}
+}
+
+func TestChatContext_MaxMessages(t *testing.T) {
+	chatCtx := NewChatContext("instructions")
+	chatCtx.SetTruncation(MaxMessages(2))
+	chatCtx.Append(
+		Message{Role: RoleUser, Content: "one"},
+		Message{Role: RoleAssistant, Content: "two"},
+		Message{Role: RoleUser, Content: "three"},
+	)
+
+	messages := chatCtx.Messages()
+	if len(messages) != 3 {
+		t.Fatalf("expected system message plus 2 history messages, got %d", len(messages))
+	}
+	if messages[1].Content != "two" || messages[2].Content != "three" {
+		t.Errorf("expected the most recent messages, got %+v", messages[1:])
+	}
+	if chatCtx.Len() != 3 {
+		t.Errorf("truncation should not modify the stored history, got %d messages", chatCtx.Len())
+	}
+}
+
+func TestChatContext_TokenBudget(t *testing.T) {
+	chatCtx := NewChatContext("")
+	chatCtx.SetTruncation(TokenBudget{
+		MaxTokens: 7,
+		Estimate:  func(msg Message) int { return len(strings.Fields(msg.Content)) },
+	})
+	chatCtx.Append(
+		Message{Role: RoleUser, Content: "one two three four five six"},
+		Message{Role: RoleAssistant, Content: "one two three four"},
+		Message{Role: RoleFunction, Name: "lookup", Content: "a b c"},
+		Message{Role: RoleUser, Content: "one two three"},
+	)
+
+	messages := chatCtx.Messages()
+	// The budget keeps the last two messages, then the orphaned function result is dropped
+	if len(messages) != 1 || messages[0].Content != "one two three" {
+		t.Errorf("unexpected truncated messages: %+v", messages)
+	}
+}
+
+func TestChatContext_Copy(t *testing.T) {
+	chatCtx := NewChatContext("instructions")
+	chatCtx.Append(Message{Role: RoleUser, Content: "hello"})
+	chatCtx.AddToolCall(ToolCallRecord{Name: "lookup", Result: "ok"})
+
+	clone := chatCtx.Copy()
+	clone.Append(Message{Role: RoleAssistant, Content: "hi"})
+	clone.SetInstructions("other")
+
+	if chatCtx.Len() != 1 || chatCtx.Instructions() != "instructions" {
+		t.Error("modifying the copy should not affect the original")
+	}
+	if len(clone.ToolCalls()) != 1 {
+		t.Error("expected tool call records to be copied")
+	}
+}
+
+func TestChatContext_ReplaceHistory(t *testing.T) {
+	chatCtx := NewChatContext("instructions")
+	chatCtx.Append(Message{Role: RoleUser, Content: "hello"})
+	chatCtx.AddToolCall(ToolCallRecord{Name: "lookup"})
+
+	chatCtx.ReplaceHistory(Message{Role: RoleSystem, Content: "summary"})
+
+	history := chatCtx.History()
+	if len(history) != 1 || history[0].Content != "summary" {
+		t.Errorf("expected history to be replaced, got %+v", history)
+	}
+	if chatCtx.Instructions() != "instructions" || len(chatCtx.ToolCalls()) != 1 {
+		t.Error("expected instructions and tool call records to be kept")
+	}
+}
+
+func TestChatContext_ReplacePrefix(t *testing.T) {
+	chatCtx := NewChatContext("")
+	chatCtx.Append(Message{Role: RoleUser, Content: "hello"}, Message{Role: RoleAssistant, Content: "hi"})
+	snapshot := chatCtx.History()
+	chatCtx.Append(Message{Role: RoleUser, Content: "still there?"})
+
+	chatCtx.ReplacePrefix(len(snapshot), Message{Role: RoleSystem, Content: "summary"})
+
+	history := chatCtx.History()
+	if len(history) != 2 || history[0].Content != "summary" || history[1].Content != "still there?" {
+		t.Errorf("expected the summary followed by the newer message, got %+v", history)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	short := EstimateTokens(Message{Role: RoleUser, Content: "hi"})
+	long := EstimateTokens(Message{Role: RoleUser, Content: strings.Repeat("word ", 100)})
+	if short <= 0 || long <= short {
+		t.Errorf("expected longer messages to cost more tokens, got %d and %d", short, long)
+	}
+}
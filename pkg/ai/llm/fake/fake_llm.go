@@ -12,6 +12,7 @@ import (
 type FakeLLM struct {
 	responses []string
 	callCount int
+	streaming bool
 }
 
 // NewFakeLLM creates a new fake LLM provider with predefined responses.
@@ -26,6 +27,14 @@ func NewFakeLLM(responses ...string) *FakeLLM {
 	return &FakeLLM{responses: responses}
 }
 
+// NewFakeStreamingLLM creates a fake LLM provider that reports streaming support
+// and emits its responses word by word from ChatStream.
+func NewFakeStreamingLLM(responses ...string) *FakeLLM {
+	f := NewFakeLLM(responses...)
+	f.streaming = true
+	return f
+}
+
 // Chat processes a chat request and returns a fake response.
 func (f *FakeLLM) Chat(ctx context.Context, req llm.ChatRequest) (llm.ChatResponse, error) {
 	// Simple response selection based on call count
@@ -33,19 +42,37 @@ func (f *FakeLLM) Chat(ctx context.Context, req llm.ChatRequest) (llm.ChatRespon
 	response := f.responses[responseIndex]
 	f.callCount++
 	
-	// If the user mentions a function, return a fake function call
+	// If the user mentions a function, return a fake call to the first function.
+	// Mentioning functions calls every function in parallel.
 	if len(req.Functions) > 0 {
 		for _, msg := range req.Messages {
-			if msg.Role == llm.RoleUser && strings.Contains(strings.ToLower(msg.Content), "function") {
+			content := strings.ToLower(msg.Content)
+			if msg.Role == llm.RoleUser && strings.Contains(content, "function") {
+				functions := req.Functions[:1]
+				if strings.Contains(content, "functions") {
+					functions = req.Functions
+				}
+				
+				toolCalls := make([]llm.ToolCall, len(functions))
+				for i, fn := range functions {
+					toolCalls[i] = llm.ToolCall{
+						ID:        fmt.Sprintf("call_%d_%d", f.callCount, i),
+						Name:      fn.Name,
+						Arguments: `{"param": "fake_value"}`,
+					}
+				}
+				
 				return llm.ChatResponse{
 					Message: llm.Message{
-						Role:    llm.RoleAssistant,
-						Content: "",
+						Role:      llm.RoleAssistant,
+						Content:   "",
+						ToolCalls: toolCalls,
 					},
 					FunctionCall: &llm.FunctionCall{
-						Name:      req.Functions[0].Name,
-						Arguments: `{"param": "fake_value"}`,
+						Name:      toolCalls[0].Name,
+						Arguments: toolCalls[0].Arguments,
 					},
+					ToolCalls:    toolCalls,
 					TokensUsed:   50,
 					FinishReason: "function_call",
 				}, nil
@@ -68,6 +95,9 @@ func (f *FakeLLM) Chat(ctx context.Context, req llm.ChatRequest) (llm.ChatRespon
 		},
 		TokensUsed:   len(strings.Fields(response)) + 10,
 		FinishReason: "stop",
+
+		PromptTokens:     10,
+		CompletionTokens: len(strings.Fields(response)),
 	}, nil
 }
 
@@ -75,9 +105,49 @@ func (f *FakeLLM) Chat(ctx context.Context, req llm.ChatRequest) (llm.ChatRespon
 func (f *FakeLLM) Capabilities() llm.LLMCapabilities {
 	return llm.LLMCapabilities{
 		SupportsFunctions:   true,
-		SupportsStreaming:   false,
+		SupportsStreaming:   f.streaming,
 		MaxTokens:          4096,
 		SupportedModels:    []string{"fake-model-1", "fake-model-2"},
 		SupportsSystemRole: true,
 	}
-}
\ No newline at end of file
+}
+
+// ChatStream produces the same response as Chat, delivered as word-sized deltas.
+func (f *FakeLLM) ChatStream(ctx context.Context, req llm.ChatRequest) (<-chan llm.ChatChunk, error) {
+	resp, err := f.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make(chan llm.ChatChunk, 10)
+	go func() {
+		defer close(output)
+
+		words := strings.SplitAfter(resp.Message.Content, " ")
+		for _, word := range words {
+			if word == "" {
+				continue
+			}
+			select {
+			case output <- llm.ChatChunk{Delta: word}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case output <- llm.ChatChunk{
+			FunctionCall: resp.FunctionCall,
+			ToolCalls:    resp.ToolCalls,
+			TokensUsed:   resp.TokensUsed,
+			FinishReason: resp.FinishReason,
+
+			PromptTokens:     resp.PromptTokens,
+			CompletionTokens: resp.CompletionTokens,
+		}:
+		case <-ctx.Done():
+		}
+	}()
+
+	return output, nil
+}
@@ -104,6 +104,33 @@ func TestFakeLLMFunctionCall(t *testing.T) {
 	}
 }
 
+func TestFakeLLMParallelToolCalls(t *testing.T) {
+	provider := NewFakeLLM()
+
+	resp, err := provider.Chat(context.Background(), llm.ChatRequest{
+		Messages: []llm.Message{
+			{Role: llm.RoleUser, Content: "Please call both functions"},
+		},
+		Functions: []llm.FunctionDefinition{
+			{Name: "first_function"},
+			{Name: "second_function"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if len(resp.ToolCalls) != 2 {
+		t.Fatalf("Expected 2 tool calls, got %d", len(resp.ToolCalls))
+	}
+	if resp.ToolCalls[0].Name != "first_function" || resp.ToolCalls[1].Name != "second_function" {
+		t.Errorf("Expected tool calls in function order, got %+v", resp.ToolCalls)
+	}
+	if resp.ToolCalls[0].ID == "" || resp.ToolCalls[0].ID == resp.ToolCalls[1].ID {
+		t.Errorf("Expected unique tool call IDs, got %+v", resp.ToolCalls)
+	}
+}
+
 func TestFakeLLMResponseCycling(t *testing.T) {
 	responses := []string{"Response A", "Response B", "Response C"}
 	provider := NewFakeLLM(responses...)
@@ -180,4 +207,43 @@ func TestFakeLLMMultipleMessages(t *testing.T) {
 	if !strings.Contains(resp.Message.Content, "How are you?") {
 		t.Errorf("Expected response to include last user message, got %q", resp.Message.Content)
 	}
-}
\ No newline at end of file
+}
+func TestFakeLLMChatStream(t *testing.T) {
+	provider := NewFakeStreamingLLM("Streaming works. Really well.")
+	if !provider.Capabilities().SupportsStreaming {
+		t.Fatal("Expected SupportsStreaming to be true")
+	}
+
+	chunks, err := provider.ChatStream(context.Background(), llm.ChatRequest{
+		Messages: []llm.Message{{Role: llm.RoleAssistant, Content: "previous"}},
+	})
+	if err != nil {
+		t.Fatalf("ChatStream() error = %v", err)
+	}
+
+	var text strings.Builder
+	var deltas int
+	var finish string
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			t.Fatalf("unexpected chunk error: %v", chunk.Error)
+		}
+		if chunk.Delta != "" {
+			deltas++
+		}
+		text.WriteString(chunk.Delta)
+		if chunk.FinishReason != "" {
+			finish = chunk.FinishReason
+		}
+	}
+
+	if text.String() != "Streaming works. Really well." {
+		t.Errorf("Expected streamed text to match response, got %q", text.String())
+	}
+	if deltas < 2 {
+		t.Errorf("Expected multiple deltas, got %d", deltas)
+	}
+	if finish != "stop" {
+		t.Errorf("Expected finish reason 'stop', got %q", finish)
+	}
+}
@@ -0,0 +1,94 @@
+package llm
+
+import (
+	"context"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+)
+
+// FallbackAdapter sends requests to the first of several LLM providers that is
+// in use, failing over to the next one when a provider fails fatally or
+// repeatedly. Providers taken out of use are health checked in the background
+// and used again once they recover.
+type FallbackAdapter struct {
+	failover *ai.Failover[LLM]
+}
+
+// NewFallbackAdapter creates a FallbackAdapter for providers, in order of
+// preference. At least one provider is required.
+func NewFallbackAdapter(providers []LLM, cfg ai.FallbackConfig) *FallbackAdapter {
+	return &FallbackAdapter{failover: ai.NewFailover(providers, checkLLM, cfg)}
+}
+
+// checkLLM sends a minimal request to check that l is working.
+func checkLLM(ctx context.Context, l LLM) error {
+	_, err := l.Chat(ctx, ChatRequest{
+		Messages:  []Message{{Role: RoleUser, Content: "ping"}},
+		MaxTokens: 1,
+	})
+	return err
+}
+
+// Chat performs a chat completion request with the first provider that succeeds.
+func (f *FallbackAdapter) Chat(ctx context.Context, req ChatRequest) (ChatResponse, error) {
+	var resp ChatResponse
+	i, err := f.failover.Do(ctx, func(l LLM) error {
+		var err error
+		resp, err = l.Chat(ctx, req)
+		return err
+	})
+	if err != nil {
+		return ChatResponse{}, err
+	}
+	f.failover.Report(i, nil)
+	return resp, nil
+}
+
+// ChatStream streams a chat completion from the first provider whose stream
+// opens. An error part way through the stream counts against that provider.
+func (f *FallbackAdapter) ChatStream(ctx context.Context, req ChatRequest) (<-chan ChatChunk, error) {
+	var chunks <-chan ChatChunk
+	i, err := f.failover.Do(ctx, func(l LLM) error {
+		var err error
+		chunks, err = ChatStream(ctx, l, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reportChunks(ctx, chunks, func(err error) { f.failover.Report(i, err) }), nil
+}
+
+// Capabilities returns the capabilities of the primary provider.
+func (f *FallbackAdapter) Capabilities() LLMCapabilities {
+	return f.failover.Primary().Capabilities()
+}
+
+// Close stops health checks of providers out of use.
+func (f *FallbackAdapter) Close() error {
+	f.failover.Close()
+	return nil
+}
+
+// reportChunks forwards chunks and calls report with the stream's error, or
+// nil, once they have all been delivered.
+func reportChunks(ctx context.Context, chunks <-chan ChatChunk, report func(error)) <-chan ChatChunk {
+	reported := make(chan ChatChunk, 10)
+	go func() {
+		defer close(reported)
+		var streamErr error
+		for chunk := range chunks {
+			if chunk.Error != nil {
+				streamErr = chunk.Error
+			}
+			// Chunks produced after cancellation are discarded
+			select {
+			case reported <- chunk:
+			case <-ctx.Done():
+			}
+		}
+		report(streamErr)
+	}()
+	return reported
+}
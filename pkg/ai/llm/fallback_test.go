@@ -0,0 +1,119 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+)
+
+// scriptedLLM answers with its name and fails with the queued errors first.
+type scriptedLLM struct {
+	name string
+
+	mu     sync.Mutex
+	errs   []error
+	calls  int
+	stream error // error delivered on the stream after the first delta
+}
+
+func (s *scriptedLLM) Chat(ctx context.Context, req ChatRequest) (ChatResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return ChatResponse{}, err
+	}
+	return ChatResponse{Message: Message{Role: RoleAssistant, Content: s.name}}, nil
+}
+
+func (s *scriptedLLM) ChatStream(ctx context.Context, req ChatRequest) (<-chan ChatChunk, error) {
+	resp, err := s.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make(chan ChatChunk, 2)
+	chunks <- ChatChunk{Delta: resp.Message.Content}
+	if s.stream != nil {
+		chunks <- ChatChunk{Error: s.stream}
+	} else {
+		chunks <- ChatChunk{FinishReason: "stop"}
+	}
+	close(chunks)
+	return chunks, nil
+}
+
+func (s *scriptedLLM) Capabilities() LLMCapabilities {
+	return LLMCapabilities{SupportsStreaming: true}
+}
+
+func recoverable(msg string) error {
+	return ai.NewRecoverableError(errors.New(msg), msg)
+}
+
+func TestRetryLLM_Chat(t *testing.T) {
+	provider := &scriptedLLM{name: "primary", errs: []error{recoverable("rate limited")}}
+	retry := NewRetryLLM(provider, ai.RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond})
+
+	resp, err := retry.Chat(context.Background(), ChatRequest{})
+	if err != nil || resp.Message.Content != "primary" {
+		t.Fatalf("expected a response after retrying, got %+v, %v", resp, err)
+	}
+	if provider.calls != 2 {
+		t.Errorf("expected 2 calls, got %d", provider.calls)
+	}
+
+	provider.errs = []error{ai.NewFatalError(errors.New("bad key"), "invalid API key")}
+	if _, err := retry.Chat(context.Background(), ChatRequest{}); !ai.IsFatal(err) {
+		t.Errorf("expected the fatal error without retrying, got %v", err)
+	}
+}
+
+func TestFallbackAdapter_Chat(t *testing.T) {
+	primary := &scriptedLLM{name: "primary", errs: []error{ai.NewFatalError(errors.New("bad key"), "invalid API key")}}
+	secondary := &scriptedLLM{name: "secondary"}
+	adapter := NewFallbackAdapter([]LLM{primary, secondary}, ai.FallbackConfig{HealthCheckInterval: time.Hour})
+	defer adapter.Close()
+
+	for i := 0; i < 2; i++ {
+		resp, err := adapter.Chat(context.Background(), ChatRequest{})
+		if err != nil || resp.Message.Content != "secondary" {
+			t.Fatalf("call %d: expected the secondary, got %+v, %v", i, resp, err)
+		}
+	}
+	if primary.calls != 1 {
+		t.Errorf("expected the primary to be out of use after a fatal error, got %d calls", primary.calls)
+	}
+}
+
+func TestFallbackAdapter_StreamErrorsCount(t *testing.T) {
+	primary := &scriptedLLM{name: "primary", stream: recoverable("connection reset")}
+	secondary := &scriptedLLM{name: "secondary"}
+	adapter := NewFallbackAdapter([]LLM{primary, secondary}, ai.FallbackConfig{MaxFailures: 2, HealthCheckInterval: time.Hour})
+	defer adapter.Close()
+
+	var got []string
+	for i := 0; i < 3; i++ {
+		chunks, err := adapter.ChatStream(context.Background(), ChatRequest{})
+		if err != nil {
+			t.Fatalf("ChatStream failed: %v", err)
+		}
+		first := <-chunks
+		for range chunks {
+		}
+		got = append(got, first.Delta)
+	}
+
+	want := []string{"primary", "primary", "secondary"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected streams from %v, got %v", want, got)
+			break
+		}
+	}
+}
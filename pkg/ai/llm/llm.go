@@ -25,13 +25,16 @@ const (
 	RoleUser      MessageRole = "user"
 	RoleAssistant MessageRole = "assistant"
 	RoleFunction  MessageRole = "function"
+	RoleTool      MessageRole = "tool"
 )
 
 // Message represents a single message in a chat conversation.
 type Message struct {
-	Role    MessageRole
-	Content string
-	Name    string // for function messages
+	Role       MessageRole
+	Content    string
+	Name       string     // for function and tool messages
+	ToolCalls  []ToolCall // for assistant messages that requested tool calls
+	ToolCallID string     // for tool messages, the ID of the call they answer
 }
 
 // FunctionCall represents a function call request from the LLM.
@@ -40,6 +43,15 @@ type FunctionCall struct {
 	Arguments string // JSON-encoded arguments
 }
 
+// ToolCall represents one of possibly several tool calls requested by the LLM
+// in a single response. Results are sent back as RoleTool messages whose
+// ToolCallID matches ID.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string // JSON-encoded arguments
+}
+
 // ChatRequest contains parameters for a chat completion request.
 type ChatRequest struct {
 	Messages    []Message
@@ -52,9 +64,29 @@ type ChatRequest struct {
 // ChatResponse contains the response from a chat completion request.
 type ChatResponse struct {
 	Message      Message
-	FunctionCall *FunctionCall
+	FunctionCall *FunctionCall // First tool call, for callers that handle a single call
+	ToolCalls    []ToolCall    // All tool calls requested by the LLM, in order
 	TokensUsed   int
 	FinishReason string
+
+	// Split of TokensUsed, if the provider reports it
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// ChatChunk is an incremental piece of a streamed chat completion.
+// The final chunk carries FinishReason, token usage and any requested tool calls.
+type ChatChunk struct {
+	Delta        string        // Incremental assistant text (may be empty)
+	FunctionCall *FunctionCall // Set on the final chunk to the first requested tool call
+	ToolCalls    []ToolCall    // Set on the final chunk when the LLM requested tool calls
+	TokensUsed   int           // Set on the final chunk if the provider reports usage
+	FinishReason string        // Set on the final chunk
+	Error        error         // Error details (terminates the stream)
+
+	// Split of TokensUsed, set on the final chunk if the provider reports it
+	PromptTokens     int
+	CompletionTokens int
 }
 
 // FunctionDefinition defines a function that the LLM can call.
@@ -80,4 +112,40 @@ type LLM interface {
 	
 	// Capabilities returns the provider's capabilities.
 	Capabilities() LLMCapabilities
-}
\ No newline at end of file
+}
+
+// StreamingLLM is implemented by LLM providers that can stream token deltas.
+// Callers should only use ChatStream when Capabilities().SupportsStreaming is true.
+type StreamingLLM interface {
+	LLM
+
+	// ChatStream performs a chat completion request and streams the response.
+	// The returned channel is closed when the completion finishes, fails or ctx is cancelled.
+	ChatStream(ctx context.Context, req ChatRequest) (<-chan ChatChunk, error)
+}
+
+// ChatStream streams a response from model. Models that cannot stream deliver
+// their whole response as a single chunk.
+func ChatStream(ctx context.Context, model LLM, req ChatRequest) (<-chan ChatChunk, error) {
+	if streaming, ok := model.(StreamingLLM); ok && model.Capabilities().SupportsStreaming {
+		return streaming.ChatStream(ctx, req)
+	}
+
+	response, err := model.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make(chan ChatChunk, 1)
+	chunks <- ChatChunk{
+		Delta:        response.Message.Content,
+		FunctionCall: response.FunctionCall,
+		ToolCalls:    response.ToolCalls,
+		TokensUsed:   response.TokensUsed,
+		FinishReason: response.FinishReason,
+
+		PromptTokens:     response.PromptTokens,
+		CompletionTokens: response.CompletionTokens,
+	}
+	close(chunks)
+	return chunks, nil
+}
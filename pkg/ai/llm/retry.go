@@ -0,0 +1,51 @@
+package llm
+
+import (
+	"context"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+)
+
+// RetryLLM retries requests to an LLM provider that fail with a recoverable
+// error. Streamed responses are only retried if the stream fails to open,
+// since part of the response may already have been used.
+type RetryLLM struct {
+	llm LLM
+	cfg ai.RetryConfig
+}
+
+// NewRetryLLM wraps an LLM provider in a RetryLLM.
+func NewRetryLLM(l LLM, cfg ai.RetryConfig) *RetryLLM {
+	return &RetryLLM{llm: l, cfg: cfg}
+}
+
+// Chat performs a chat completion request, retrying recoverable failures.
+func (r *RetryLLM) Chat(ctx context.Context, req ChatRequest) (ChatResponse, error) {
+	var resp ChatResponse
+	err := ai.Retry(ctx, r.cfg, func(ctx context.Context) error {
+		var err error
+		resp, err = r.llm.Chat(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// ChatStream streams a chat completion, retrying recoverable failures to open
+// the stream.
+func (r *RetryLLM) ChatStream(ctx context.Context, req ChatRequest) (<-chan ChatChunk, error) {
+	var chunks <-chan ChatChunk
+	err := ai.Retry(ctx, r.cfg, func(ctx context.Context) error {
+		var err error
+		chunks, err = ChatStream(ctx, r.llm, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return chunks, nil
+}
+
+// Capabilities returns the wrapped provider's capabilities.
+func (r *RetryLLM) Capabilities() LLMCapabilities {
+	return r.llm.Capabilities()
+}
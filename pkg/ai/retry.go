@@ -0,0 +1,34 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Retry calls fn until it succeeds or fails with an error that is not
+// recoverable, waiting cfg.Backoff between attempts. After cfg.MaxRetries
+// retries the last error is returned. Cancelling ctx stops waiting and returns
+// the context error.
+func Retry(ctx context.Context, cfg RetryConfig, fn func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || !IsRecoverable(err) || ctx.Err() != nil {
+			return err
+		}
+		if attempt > cfg.MaxRetries {
+			if cfg.MaxRetries == 0 {
+				return err
+			}
+			return fmt.Errorf("giving up after %d retries: %w", cfg.MaxRetries, err)
+		}
+
+		timer := time.NewTimer(cfg.Backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
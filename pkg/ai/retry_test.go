@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var testRetryConfig = RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond, BackoffFactor: 2}
+
+func TestRetry_RecoverableThenSuccess(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), testRetryConfig, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return NewRecoverableError(errors.New("busy"), "service busy")
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("expected success on the third call, got %v after %d calls", err, calls)
+	}
+}
+
+func TestRetry_GivesUp(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), testRetryConfig, func(ctx context.Context) error {
+		calls++
+		return NewRecoverableError(errors.New("busy"), "service busy")
+	})
+	if !IsRecoverable(err) || calls != 3 {
+		t.Errorf("expected the recoverable error after 3 calls, got %v after %d calls", err, calls)
+	}
+}
+
+func TestRetry_FatalNotRetried(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), testRetryConfig, func(ctx context.Context) error {
+		calls++
+		return NewFatalError(errors.New("bad key"), "invalid API key")
+	})
+	if !IsFatal(err) || calls != 1 {
+		t.Errorf("expected the fatal error after 1 call, got %v after %d calls", err, calls)
+	}
+}
+
+func TestRetry_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := RetryConfig{MaxRetries: 5, InitialDelay: time.Hour}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	err := Retry(ctx, cfg, func(ctx context.Context) error {
+		return NewRecoverableError(errors.New("busy"), "service busy")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation while waiting to retry, got %v", err)
+	}
+}
+
+func TestRetryConfig_Backoff(t *testing.T) {
+	cfg := RetryConfig{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second, BackoffFactor: 2}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}
+	for i, w := range want {
+		if got := cfg.Backoff(i + 1); got != w {
+			t.Errorf("Backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+
+	cfg.JitterPercent = 0.1
+	for i := 0; i < 100; i++ {
+		if got := cfg.Backoff(1); got < 90*time.Millisecond || got > 110*time.Millisecond {
+			t.Fatalf("Backoff with 10%% jitter = %v, want within 90ms-110ms", got)
+		}
+	}
+}
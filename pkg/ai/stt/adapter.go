@@ -0,0 +1,267 @@
+package stt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/vad"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// StreamAdapter implements streaming STT on top of a Recognizer, for
+// providers without a streaming API. A VAD cuts the pushed audio into speech
+// segments, and each segment is transcribed once it ends, giving exactly one
+// final event per segment with the segment's offsets in the stream. Without
+// a VAD, the whole stream is a single segment transcribed after CloseSend.
+//
+// Start and end of speech events follow the VAD, and every final event is
+// followed by a recognition usage event for its segment.
+type StreamAdapter struct {
+	recognizer Recognizer
+	vad        vad.VAD
+}
+
+// NewStreamAdapter creates a StreamAdapter. detector may be nil.
+func NewStreamAdapter(recognizer Recognizer, detector vad.VAD) *StreamAdapter {
+	return &StreamAdapter{recognizer: recognizer, vad: detector}
+}
+
+// NewStream creates a new streaming STT session.
+func (a *StreamAdapter) NewStream(ctx context.Context, cfg StreamConfig) (STTStream, error) {
+	stream := &adapterStream{
+		adapter: a,
+		ctx:     ctx,
+		opts:    RecognizeOptions{Language: cfg.Lang},
+		events:  make(chan SpeechEvent, 10),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	if a.vad == nil {
+		go stream.recognizeAll()
+		return stream, nil
+	}
+
+	stream.frames = make(chan rtc.AudioFrame, 100)
+	vadEvents, err := a.vad.Detect(ctx, stream.frames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start VAD: %w", err)
+	}
+	go stream.recognizeSegments(vadEvents)
+	return stream, nil
+}
+
+// Capabilities returns the capabilities of the adapter. Languages and sample
+// rates come from the recognizer if it reports its own capabilities, and
+// sample rates from the VAD instead if it restricts them.
+func (a *StreamAdapter) Capabilities() STTCapabilities {
+	caps := STTCapabilities{Streaming: true}
+	if provider, ok := a.recognizer.(interface{ Capabilities() STTCapabilities }); ok {
+		inner := provider.Capabilities()
+		caps.SupportedLanguages = inner.SupportedLanguages
+		caps.SampleRates = inner.SampleRates
+	}
+	if a.vad != nil {
+		if rates := a.vad.Capabilities().SampleRates; len(rates) > 0 {
+			caps.SampleRates = rates
+		}
+	}
+	return caps
+}
+
+// adapterStream is a stream of a StreamAdapter.
+type adapterStream struct {
+	adapter *StreamAdapter
+	ctx     context.Context
+	opts    RecognizeOptions
+	events  chan SpeechEvent
+
+	mu     sync.Mutex
+	closed bool
+	offset time.Duration // audio pushed so far
+
+	frames  chan rtc.AudioFrame // input of the VAD, if any
+	buffer  []rtc.AudioFrame    // audio of the stream without a VAD
+	done    chan struct{}       // closed by CloseSend
+	stopped chan struct{}       // closed when the stream stops taking audio
+}
+
+// segmentQueueSize is the number of ended speech segments that may wait for
+// recognition before the VAD, and with it Push, is held up.
+const segmentQueueSize = 16
+
+// segment is a speech segment waiting for recognition, or a VAD error to be
+// reported in order with the transcripts.
+type segment struct {
+	frames []rtc.AudioFrame
+	err    error
+}
+
+// Push sends an audio frame for processing. The frame's Timestamp is set to
+// its offset in the stream.
+func (s *adapterStream) Push(frame rtc.AudioFrame) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("stream is closed")
+	}
+	frame.Timestamp = s.offset
+	s.offset += frame.Duration()
+	if s.frames == nil {
+		s.buffer = append(s.buffer, frame)
+		return nil
+	}
+
+	// Holding mu keeps CloseSend from closing frames during the send
+	select {
+	case s.frames <- frame:
+		return nil
+	case <-s.stopped:
+		return fmt.Errorf("stream stopped")
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+// Events returns the channel for receiving speech events.
+func (s *adapterStream) Events() <-chan SpeechEvent {
+	return s.events
+}
+
+// CloseSend signals that no more audio will be sent. Speech in progress is
+// ended and transcribed.
+func (s *adapterStream) CloseSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("stream already closed")
+	}
+	s.closed = true
+	close(s.done)
+	if s.frames != nil {
+		close(s.frames)
+	}
+	return nil
+}
+
+// recognizeSegments queues each speech segment the VAD reports until the
+// VAD stops. Segments are transcribed in order by a separate goroutine, so a
+// slow recognizer does not hold up the audio pushed meanwhile. The start and
+// end of speech are sent as soon as the VAD reports them, which may be before
+// the transcripts of earlier segments.
+func (s *adapterStream) recognizeSegments(vadEvents <-chan vad.VADEvent) {
+	segments := make(chan segment, segmentQueueSize)
+	go s.recognizeQueued(segments)
+	defer close(segments)
+	defer close(s.stopped)
+
+	for event := range vadEvents {
+		var next segment
+		switch event.Type {
+		case vad.VADEventSpeechStart:
+			s.send(SpeechEvent{Type: SpeechEventStartOfSpeech})
+			continue
+		case vad.VADEventSpeechEnd:
+			s.send(SpeechEvent{Type: SpeechEventEndOfSpeech})
+			next.frames = event.Audio()
+		case vad.VADEventError:
+			next.err = event.Error
+		default:
+			continue
+		}
+
+		select {
+		case segments <- next:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// recognizeQueued transcribes queued segments until the queue is closed.
+func (s *adapterStream) recognizeQueued(segments <-chan segment) {
+	defer close(s.events)
+
+	for next := range segments {
+		if next.err != nil {
+			s.send(SpeechEvent{Type: SpeechEventError, Error: next.err})
+			continue
+		}
+		s.recognize(next.frames)
+	}
+}
+
+// recognizeAll transcribes all audio of the stream after CloseSend.
+func (s *adapterStream) recognizeAll() {
+	defer close(s.events)
+	defer close(s.stopped)
+
+	select {
+	case <-s.done:
+	case <-s.ctx.Done():
+		return
+	}
+
+	s.mu.Lock()
+	frames := s.buffer
+	s.buffer = nil
+	s.mu.Unlock()
+
+	if len(frames) > 0 {
+		s.recognize(frames)
+	}
+}
+
+// recognize transcribes a segment and sends its final and usage events, or
+// an error event if recognition fails.
+func (s *adapterStream) recognize(frames []rtc.AudioFrame) {
+	if len(frames) == 0 {
+		return
+	}
+
+	result, err := s.adapter.recognizer.Recognize(s.ctx, frames, s.opts)
+	if err != nil {
+		s.send(SpeechEvent{Type: SpeechEventError, Error: err})
+		return
+	}
+
+	// Word timings are relative to the segment
+	last := frames[len(frames)-1]
+	data := SpeechData{
+		Text:       result.Text,
+		Language:   result.Language,
+		Confidence: result.Confidence,
+		StartTime:  frames[0].Timestamp,
+		EndTime:    last.Timestamp + last.Duration(),
+	}
+	for _, word := range result.Words {
+		word.Start += data.StartTime
+		word.End += data.StartTime
+		data.Words = append(data.Words, word)
+	}
+
+	s.send(SpeechEvent{
+		Type:         SpeechEventFinal,
+		Text:         data.Text,
+		IsFinal:      true,
+		Language:     data.Language,
+		Alternatives: []SpeechData{data},
+	})
+	s.send(SpeechEvent{
+		Type:  SpeechEventRecognitionUsage,
+		Usage: &RecognitionUsage{AudioDuration: data.EndTime - data.StartTime},
+	})
+}
+
+// send sends an event unless the stream's context is done.
+func (s *adapterStream) send(event SpeechEvent) {
+	event.Timestamp = time.Now().UnixMilli()
+	select {
+	case s.events <- event:
+	case <-s.ctx.Done():
+	}
+}
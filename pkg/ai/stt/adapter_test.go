@@ -0,0 +1,281 @@
+package stt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/vad"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// levelVAD treats frames with a non-zero first sample as speech, ending a
+// segment at the first silent frame after it.
+type levelVAD struct{}
+
+func (levelVAD) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-chan vad.VADEvent, error) {
+	events := make(chan vad.VADEvent, 10)
+	go func() {
+		defer close(events)
+		var speech []rtc.AudioFrame
+		for frame := range frames {
+			if frame.Data[0] != 0 {
+				if len(speech) == 0 {
+					events <- vad.VADEvent{Type: vad.VADEventSpeechStart}
+				}
+				speech = append(speech, frame)
+				continue
+			}
+			if len(speech) > 0 {
+				events <- vad.VADEvent{Type: vad.VADEventSpeechEnd, Frames: speech}
+				speech = nil
+			}
+		}
+		if len(speech) > 0 {
+			events <- vad.VADEvent{Type: vad.VADEventSpeechEnd, Frames: speech}
+		}
+	}()
+	return events, nil
+}
+
+func (levelVAD) Capabilities() vad.VADCapabilities {
+	return vad.VADCapabilities{SampleRates: []int{16000}}
+}
+
+// countingRecognizer transcribes each segment as the number of frames in it.
+// Its single word spans the first frame.
+func countingRecognizer(ctx context.Context, frames []rtc.AudioFrame, opts RecognizeOptions) (*Recognition, error) {
+	return &Recognition{
+		Text:       fmt.Sprintf("%d frames", len(frames)),
+		Language:   opts.Language,
+		Confidence: 0.9,
+		Words:      []Word{{Text: "frames", End: 10 * time.Millisecond}},
+	}, nil
+}
+
+// testFrame returns a 10ms frame of 16 kHz mono audio, silent unless speech.
+func testFrame(speech bool) rtc.AudioFrame {
+	data := make([]byte, 320)
+	if speech {
+		data[0] = 1
+	}
+	return rtc.AudioFrame{Data: data, SampleRate: 16000, NumChannels: 1, SamplesPerChannel: 160}
+}
+
+func collect(events <-chan SpeechEvent) []SpeechEvent {
+	var result []SpeechEvent
+	for event := range events {
+		result = append(result, event)
+	}
+	return result
+}
+
+func TestStreamAdapter_FinalPerSegment(t *testing.T) {
+	adapter := NewStreamAdapter(RecognizerFunc(countingRecognizer), levelVAD{})
+	stream, err := adapter.NewStream(context.Background(), StreamConfig{SampleRate: 16000, NumChannels: 1, Lang: "en"})
+	if err != nil {
+		t.Fatalf("NewStream failed: %v", err)
+	}
+
+	// Two segments: frames 2-4 and frames 7-8, the second still open at close
+	pattern := []bool{false, false, true, true, true, false, false, true, true}
+	for _, speech := range pattern {
+		if err := stream.Push(testFrame(speech)); err != nil {
+			t.Fatalf("Push failed: %v", err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend failed: %v", err)
+	}
+
+	// Transcripts follow the end of their segment, but the next segment may
+	// start before the previous one is transcribed
+	events := collect(stream.Events())
+	var boundaries, results []SpeechEventType
+	var finals []SpeechEvent
+	var usage time.Duration
+	for _, event := range events {
+		switch event.Type {
+		case SpeechEventStartOfSpeech, SpeechEventEndOfSpeech:
+			boundaries = append(boundaries, event.Type)
+		case SpeechEventFinal:
+			if len(boundaries) < 2*(len(finals)+1) {
+				t.Errorf("final %d arrived before the end of its segment", len(finals))
+			}
+			results = append(results, event.Type)
+			finals = append(finals, event)
+		case SpeechEventRecognitionUsage:
+			results = append(results, event.Type)
+			usage += event.Usage.AudioDuration
+		}
+	}
+
+	segment := []SpeechEventType{SpeechEventStartOfSpeech, SpeechEventEndOfSpeech}
+	if want := append(segment, segment...); fmt.Sprint(boundaries) != fmt.Sprint(want) {
+		t.Fatalf("expected speech boundaries %v, got %v", want, boundaries)
+	}
+	result := []SpeechEventType{SpeechEventFinal, SpeechEventRecognitionUsage}
+	if want := append(result, result...); fmt.Sprint(results) != fmt.Sprint(want) {
+		t.Fatalf("expected results %v, got %v", want, results)
+	}
+	if usage != 50*time.Millisecond {
+		t.Errorf("expected usage for 50ms of speech, got %v", usage)
+	}
+
+	want := []struct {
+		text       string
+		start, end time.Duration
+	}{
+		{"3 frames", 20 * time.Millisecond, 50 * time.Millisecond},
+		{"2 frames", 70 * time.Millisecond, 90 * time.Millisecond},
+	}
+	for i, w := range want {
+		event := finals[i]
+		if !event.IsFinal || event.Text != w.text || event.Language != "en" || len(event.Alternatives) != 1 {
+			t.Errorf("segment %d: unexpected event %+v", i, event)
+			continue
+		}
+		data := event.Alternatives[0]
+		if data.Text != w.text || data.Confidence != 0.9 || data.StartTime != w.start || data.EndTime != w.end {
+			t.Errorf("segment %d: expected %q at %v-%v, got %+v", i, w.text, w.start, w.end, data)
+		}
+		if len(data.Words) != 1 || data.Words[0].Start != w.start || data.Words[0].End != w.start+10*time.Millisecond {
+			t.Errorf("segment %d: expected word timings relative to the stream, got %+v", i, data.Words)
+		}
+	}
+
+	if err := stream.Push(testFrame(true)); err == nil {
+		t.Error("expected an error when pushing to a closed stream")
+	}
+}
+
+func TestStreamAdapter_WithoutVAD(t *testing.T) {
+	adapter := NewStreamAdapter(RecognizerFunc(countingRecognizer), nil)
+	stream, err := adapter.NewStream(context.Background(), StreamConfig{})
+	if err != nil {
+		t.Fatalf("NewStream failed: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		stream.Push(testFrame(i%2 == 0))
+	}
+	stream.CloseSend()
+
+	events := collect(stream.Events())
+	if len(events) != 2 || events[0].Text != "5 frames" || events[0].Alternatives[0].EndTime != 50*time.Millisecond {
+		t.Errorf("expected a single final event for the whole stream, got %+v", events)
+	}
+	if events[1].Type != SpeechEventRecognitionUsage || events[1].Usage.AudioDuration != 50*time.Millisecond {
+		t.Errorf("expected a usage event for the whole stream, got %+v", events[1])
+	}
+}
+
+func TestStreamAdapter_RecognitionError(t *testing.T) {
+	failing := RecognizerFunc(func(ctx context.Context, frames []rtc.AudioFrame, opts RecognizeOptions) (*Recognition, error) {
+		return nil, errors.New("service unavailable")
+	})
+	adapter := NewStreamAdapter(failing, levelVAD{})
+	stream, err := adapter.NewStream(context.Background(), StreamConfig{})
+	if err != nil {
+		t.Fatalf("NewStream failed: %v", err)
+	}
+
+	stream.Push(testFrame(true))
+	stream.CloseSend()
+
+	events := collect(stream.Events())
+	last := events[len(events)-1]
+	if last.Type != SpeechEventError || last.Error == nil {
+		t.Errorf("expected an error event, got %+v", events)
+	}
+}
+
+func TestStreamAdapter_QueuesSegmentsDuringRecognition(t *testing.T) {
+	release := make(chan struct{})
+	slow := RecognizerFunc(func(ctx context.Context, frames []rtc.AudioFrame, opts RecognizeOptions) (*Recognition, error) {
+		<-release
+		return countingRecognizer(ctx, frames, opts)
+	})
+	adapter := NewStreamAdapter(slow, levelVAD{})
+	stream, err := adapter.NewStream(context.Background(), StreamConfig{})
+	if err != nil {
+		t.Fatalf("NewStream failed: %v", err)
+	}
+
+	collected := make(chan []SpeechEvent, 1)
+	go func() { collected <- collect(stream.Events()) }()
+
+	// More one-frame segments than the frame and VAD event buffers hold, so
+	// pushing them only succeeds if ended segments queue for recognition
+	segments := 50 + segmentQueueSize
+	pushed := make(chan error, 1)
+	go func() {
+		for i := 0; i < segments; i++ {
+			if err := stream.Push(testFrame(true)); err != nil {
+				pushed <- err
+				return
+			}
+			if err := stream.Push(testFrame(false)); err != nil {
+				pushed <- err
+				return
+			}
+		}
+		pushed <- stream.CloseSend()
+	}()
+
+	select {
+	case err := <-pushed:
+		if err != nil {
+			t.Fatalf("Push failed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Push blocked while the first segment was being recognized")
+	}
+
+	close(release)
+	var finals []SpeechEvent
+	for _, event := range <-collected {
+		if event.Type == SpeechEventFinal {
+			finals = append(finals, event)
+		}
+	}
+	if len(finals) != segments {
+		t.Fatalf("expected %d final events, got %d", segments, len(finals))
+	}
+	for i, event := range finals {
+		if event.Text != "1 frames" || event.Alternatives[0].StartTime != time.Duration(2*i)*10*time.Millisecond {
+			t.Errorf("segment %d: unexpected event %+v", i, event)
+		}
+	}
+}
+
+// recognizingSTT is an STT provider that also implements Recognizer.
+type recognizingSTT struct {
+	eventSTT
+	RecognizerFunc
+}
+
+func TestRecognize(t *testing.T) {
+	frames := []rtc.AudioFrame{testFrame(true), testFrame(true)}
+
+	// Providers that implement Recognizer are called directly
+	direct := &recognizingSTT{RecognizerFunc: countingRecognizer}
+	result, err := Recognize(context.Background(), direct, frames, RecognizeOptions{Language: "en"})
+	if err != nil || result.Text != "2 frames" || result.Language != "en" || direct.opened != 0 {
+		t.Errorf("expected the recognizer to be used, got %+v, %v after %d streams", result, err, direct.opened)
+	}
+
+	// Others are streamed the audio
+	streaming := &eventSTT{event: SpeechEvent{Type: SpeechEventFinal, Text: "hello", Language: "en-US", IsFinal: true}}
+	result, err = Recognize(context.Background(), streaming, frames, RecognizeOptions{})
+	if err != nil || result.Text != "hello" || result.Language != "en-US" || streaming.opened != 1 {
+		t.Errorf("expected the streamed transcript, got %+v, %v", result, err)
+	}
+
+	failing := &eventSTT{event: SpeechEvent{Type: SpeechEventError, Error: errors.New("bad audio")}}
+	if _, err := Recognize(context.Background(), failing, frames, RecognizeOptions{}); err == nil {
+		t.Error("expected the stream's error")
+	}
+}
@@ -0,0 +1,62 @@
+package stt
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// BreakerSTT guards an STT provider with a circuit breaker. While the breaker
+// is open, new streams and recognitions fail immediately with
+// ai.ErrCircuitOpen instead of waiting on a provider that keeps failing. A
+// stream counts as failed if it fails to open or delivers an error before its
+// first final transcript.
+type BreakerSTT struct {
+	stt     STT
+	breaker *ai.CircuitBreaker
+}
+
+// NewBreakerSTT wraps an STT provider in a BreakerSTT.
+func NewBreakerSTT(s STT, cfg ai.BreakerConfig) *BreakerSTT {
+	return &BreakerSTT{stt: s, breaker: ai.NewCircuitBreaker(cfg)}
+}
+
+// Breaker returns the circuit breaker guarding the provider.
+func (b *BreakerSTT) Breaker() *ai.CircuitBreaker {
+	return b.breaker
+}
+
+// NewStream opens a stream if the breaker allows it.
+func (b *BreakerSTT) NewStream(ctx context.Context, cfg StreamConfig) (STTStream, error) {
+	if err := b.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	stream, err := b.stt.NewStream(ctx, cfg)
+	if err != nil {
+		b.breaker.Record(err)
+		return nil, err
+	}
+
+	// Only the first outcome of the stream is recorded for the call Allow let through
+	var once sync.Once
+	return reportStream(ctx, stream, func(err error) {
+		once.Do(func() { b.breaker.Record(err) })
+	}), nil
+}
+
+// Recognize transcribes a complete buffer of audio if the breaker allows it.
+func (b *BreakerSTT) Recognize(ctx context.Context, frames []rtc.AudioFrame, opts RecognizeOptions) (*Recognition, error) {
+	if err := b.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := Recognize(ctx, b.stt, frames, opts)
+	b.breaker.Record(err)
+	return result, err
+}
+
+// Capabilities returns the wrapped provider's capabilities.
+func (b *BreakerSTT) Capabilities() STTCapabilities {
+	return b.stt.Capabilities()
+}
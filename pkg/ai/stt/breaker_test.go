@@ -0,0 +1,24 @@
+package stt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+func TestBreakerSTT_Recognize(t *testing.T) {
+	provider := flakyRecognizer(1, ai.NewRecoverableError(errors.New("reset"), "connection reset"))
+	breaker := NewBreakerSTT(provider, ai.BreakerConfig{Name: "test-stt", MinRequests: 1, CoolDown: time.Hour})
+
+	frames := []rtc.AudioFrame{testFrame(true)}
+	if _, err := breaker.Recognize(context.Background(), frames, RecognizeOptions{}); !ai.IsRecoverable(err) {
+		t.Errorf("expected the provider's error, got %v", err)
+	}
+	if _, err := breaker.Recognize(context.Background(), frames, RecognizeOptions{}); !errors.Is(err, ai.ErrCircuitOpen) {
+		t.Errorf("expected the failure to open the breaker, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package fake
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/chriscow/livekit-agents-go/pkg/ai/stt"
@@ -38,6 +39,37 @@ func (f *FakeSTT) NewStream(ctx context.Context, cfg stt.StreamConfig) (stt.STTS
 	}, nil
 }
 
+// Recognize returns the fixed transcript, with its words spread evenly over
+// the duration of frames.
+func (f *FakeSTT) Recognize(ctx context.Context, frames []rtc.AudioFrame, opts stt.RecognizeOptions) (*stt.Recognition, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var duration time.Duration
+	for _, frame := range frames {
+		if frame.SampleRate > 0 {
+			duration += time.Duration(frame.SamplesPerChannel) * time.Second / time.Duration(frame.SampleRate)
+		}
+	}
+
+	language := opts.Language
+	if language == "" {
+		language = "en-US"
+	}
+	result := &stt.Recognition{Text: f.transcript, Language: language, Confidence: 1}
+	words := strings.Fields(f.transcript)
+	for i, word := range words {
+		result.Words = append(result.Words, stt.Word{
+			Text:       word,
+			Start:      duration * time.Duration(i) / time.Duration(len(words)),
+			End:        duration * time.Duration(i+1) / time.Duration(len(words)),
+			Confidence: 1,
+		})
+	}
+	return result, nil
+}
+
 // Capabilities returns the fake STT capabilities.
 func (f *FakeSTT) Capabilities() stt.STTCapabilities {
 	return stt.STTCapabilities{
@@ -54,6 +86,7 @@ type FakeSTTStream struct {
 	events      chan stt.SpeechEvent
 	ctx         context.Context
 	frameCount  int
+	duration    time.Duration
 	closed      bool
 }
 
@@ -64,6 +97,9 @@ func (s *FakeSTTStream) Push(frame rtc.AudioFrame) error {
 	}
 
 	s.frameCount++
+	if frame.SampleRate > 0 {
+		s.duration += time.Duration(frame.SamplesPerChannel) * time.Second / time.Duration(frame.SampleRate)
+	}
 	
 	// Send interim result every InterimResultFrameInterval frames
 	if s.frameCount%InterimResultFrameInterval == 0 {
@@ -104,6 +140,12 @@ func (s *FakeSTTStream) CloseSend() error {
 		IsFinal:   true,
 		Language:  "en-US",
 		Timestamp: time.Now().UnixMilli(),
+		Alternatives: []stt.SpeechData{{
+			Text:       s.transcript,
+			Language:   "en-US",
+			Confidence: 1,
+			EndTime:    s.duration,
+		}},
 	}:
 	case <-s.ctx.Done():
 		close(s.events)
@@ -135,4 +135,28 @@ func TestFakeSTTStreamClosedPush(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error when pushing to closed stream")
 	}
-}
\ No newline at end of file
+}
+func TestFakeSTTRecognize(t *testing.T) {
+	provider := NewFakeSTT("Hello world")
+
+	frames := make([]rtc.AudioFrame, 100) // 1 second
+	for i := range frames {
+		frames[i] = rtc.AudioFrame{
+			Data:              make([]byte, 320),
+			SampleRate:        16000,
+			SamplesPerChannel: 160,
+			NumChannels:       1,
+		}
+	}
+
+	result, err := stt.Recognize(context.Background(), provider, frames, stt.RecognizeOptions{Language: "en-GB"})
+	if err != nil {
+		t.Fatalf("Recognize() error = %v", err)
+	}
+	if result.Text != "Hello world" || result.Language != "en-GB" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if len(result.Words) != 2 || result.Words[1].Start != 500*time.Millisecond || result.Words[1].End != time.Second {
+		t.Errorf("Expected two words over one second, got %+v", result.Words)
+	}
+}
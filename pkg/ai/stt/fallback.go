@@ -0,0 +1,136 @@
+package stt
+
+import (
+	"context"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// FallbackAdapter opens streams on the first of several STT providers that is
+// in use, failing over to the next one when a provider fails fatally or
+// repeatedly. Error events on a stream count against its provider, so the
+// next stream is opened elsewhere. Batch recognitions fail over the same way.
+// Providers taken out of use are health checked in the background and used
+// again once they recover.
+type FallbackAdapter struct {
+	failover *ai.Failover[STT]
+}
+
+// NewFallbackAdapter creates a FallbackAdapter for providers, in order of
+// preference. At least one provider is required.
+func NewFallbackAdapter(providers []STT, cfg ai.FallbackConfig) *FallbackAdapter {
+	return &FallbackAdapter{failover: ai.NewFailover(providers, checkSTT, cfg)}
+}
+
+// checkSTT opens and closes a stream to check that s is working.
+func checkSTT(ctx context.Context, s STT) error {
+	cfg := StreamConfig{SampleRate: 16000, NumChannels: 1}
+	if rates := s.Capabilities().SampleRates; len(rates) > 0 {
+		cfg.SampleRate = rates[0]
+	}
+	stream, err := s.NewStream(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case event, ok := <-stream.Events():
+			if !ok {
+				return nil
+			}
+			if event.Type == SpeechEventError {
+				return event.Error
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// NewStream opens a stream on the first provider that accepts it.
+func (f *FallbackAdapter) NewStream(ctx context.Context, cfg StreamConfig) (STTStream, error) {
+	var stream STTStream
+	i, err := f.failover.Do(ctx, func(s STT) error {
+		var err error
+		stream, err = s.NewStream(ctx, cfg)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reportStream(ctx, stream, func(err error) { f.failover.Report(i, err) }), nil
+}
+
+// Recognize transcribes a complete buffer of audio with the first provider
+// that succeeds.
+func (f *FallbackAdapter) Recognize(ctx context.Context, frames []rtc.AudioFrame, opts RecognizeOptions) (*Recognition, error) {
+	var result *Recognition
+	i, err := f.failover.Do(ctx, func(s STT) error {
+		var err error
+		result, err = Recognize(ctx, s, frames, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f.failover.Report(i, nil)
+	return result, nil
+}
+
+// Capabilities returns the capabilities of the primary provider.
+func (f *FallbackAdapter) Capabilities() STTCapabilities {
+	return f.failover.Primary().Capabilities()
+}
+
+// Close stops health checks of providers out of use.
+func (f *FallbackAdapter) Close() error {
+	f.failover.Close()
+	return nil
+}
+
+// reportingStream forwards the events of a stream so that errors can be
+// reported against its provider.
+type reportingStream struct {
+	STTStream
+	events chan SpeechEvent
+}
+
+// reportStream wraps stream to call report with the error of each error
+// event, with nil for each final transcript, and with nil when the stream
+// ends if neither was seen.
+func reportStream(ctx context.Context, stream STTStream, report func(error)) STTStream {
+	reported := &reportingStream{STTStream: stream, events: make(chan SpeechEvent, 10)}
+	go func() {
+		defer close(reported.events)
+		outcome := false
+		for event := range stream.Events() {
+			switch event.Type {
+			case SpeechEventError:
+				outcome = true
+				report(event.Error)
+			case SpeechEventFinal:
+				outcome = true
+				report(nil)
+			}
+			// Events produced after cancellation are discarded
+			select {
+			case reported.events <- event:
+			case <-ctx.Done():
+			}
+		}
+		if !outcome {
+			report(nil)
+		}
+	}()
+	return reported
+}
+
+func (s *reportingStream) Events() <-chan SpeechEvent {
+	return s.events
+}
@@ -0,0 +1,119 @@
+package stt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// eventSTT opens streams that deliver a single event once closed.
+type eventSTT struct {
+	event   SpeechEvent
+	openErr error
+	opened  int
+}
+
+func (e *eventSTT) NewStream(ctx context.Context, cfg StreamConfig) (STTStream, error) {
+	e.opened++
+	if e.openErr != nil {
+		return nil, e.openErr
+	}
+	events := make(chan SpeechEvent, 1)
+	events <- e.event
+	close(events)
+	return &eventStream{events: events}, nil
+}
+
+func (e *eventSTT) Capabilities() STTCapabilities {
+	return STTCapabilities{SampleRates: []int{16000}}
+}
+
+type eventStream struct {
+	events chan SpeechEvent
+}
+
+func (s *eventStream) Push(frame rtc.AudioFrame) error { return nil }
+func (s *eventStream) Events() <-chan SpeechEvent      { return s.events }
+func (s *eventStream) CloseSend() error                { return nil }
+
+func TestRetrySTT(t *testing.T) {
+	provider := &eventSTT{openErr: ai.NewRecoverableError(errors.New("timeout"), "timeout")}
+	retry := NewRetrySTT(provider, ai.RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond})
+
+	if _, err := retry.NewStream(context.Background(), StreamConfig{}); !ai.IsRecoverable(err) {
+		t.Errorf("expected the recoverable error, got %v", err)
+	}
+	if provider.opened != 3 {
+		t.Errorf("expected 3 attempts, got %d", provider.opened)
+	}
+}
+
+// flakyRecognizer fails its first failures recognitions with err.
+func flakyRecognizer(failures int, err error) *recognizingSTT {
+	provider := &recognizingSTT{}
+	calls := 0
+	provider.RecognizerFunc = func(ctx context.Context, frames []rtc.AudioFrame, opts RecognizeOptions) (*Recognition, error) {
+		calls++
+		if calls <= failures {
+			return nil, err
+		}
+		return countingRecognizer(ctx, frames, opts)
+	}
+	return provider
+}
+
+func TestRetrySTT_Recognize(t *testing.T) {
+	provider := flakyRecognizer(2, ai.NewRecoverableError(errors.New("timeout"), "timeout"))
+	retry := NewRetrySTT(provider, ai.RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond})
+
+	result, err := Recognize(context.Background(), retry, []rtc.AudioFrame{testFrame(true)}, RecognizeOptions{})
+	if err != nil || result.Text != "1 frames" {
+		t.Errorf("expected the third attempt to succeed, got %+v, %v", result, err)
+	}
+	if provider.opened != 0 {
+		t.Errorf("expected the provider's Recognizer to be used, got %d streams", provider.opened)
+	}
+}
+
+func TestFallbackAdapter_ErrorEventsFailOver(t *testing.T) {
+	primary := &eventSTT{event: SpeechEvent{Type: SpeechEventError, Error: ai.NewFatalError(errors.New("bad key"), "invalid API key")}}
+	secondary := &eventSTT{event: SpeechEvent{Type: SpeechEventFinal, Text: "hello", IsFinal: true}}
+	adapter := NewFallbackAdapter([]STT{primary, secondary}, ai.FallbackConfig{HealthCheckInterval: time.Hour})
+	defer adapter.Close()
+
+	var texts []string
+	for i := 0; i < 2; i++ {
+		stream, err := adapter.NewStream(context.Background(), StreamConfig{})
+		if err != nil {
+			t.Fatalf("NewStream failed: %v", err)
+		}
+		for event := range stream.Events() {
+			texts = append(texts, event.Text)
+		}
+	}
+	if primary.opened != 1 || secondary.opened != 1 || texts[1] != "hello" {
+		t.Errorf("expected the second stream on the secondary, got %d/%d opens and %q", primary.opened, secondary.opened, texts)
+	}
+}
+
+func TestFallbackAdapter_Recognize(t *testing.T) {
+	primary := flakyRecognizer(1, ai.NewFatalError(errors.New("bad key"), "invalid API key"))
+	secondary := &eventSTT{event: SpeechEvent{Type: SpeechEventFinal, Text: "hello", IsFinal: true}}
+	adapter := NewFallbackAdapter([]STT{primary, secondary}, ai.FallbackConfig{HealthCheckInterval: time.Hour})
+	defer adapter.Close()
+
+	frames := []rtc.AudioFrame{testFrame(true)}
+	for i := 0; i < 2; i++ {
+		result, err := Recognize(context.Background(), adapter, frames, RecognizeOptions{})
+		if err != nil || result.Text != "hello" {
+			t.Errorf("recognition %d: expected the secondary's transcript, got %+v, %v", i, result, err)
+		}
+	}
+	if secondary.opened != 2 {
+		t.Errorf("expected both recognitions streamed to the secondary, got %d", secondary.opened)
+	}
+}
@@ -0,0 +1,132 @@
+package stt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// RecognizeOptions configures a batch recognition.
+type RecognizeOptions struct {
+	// Language is the language of the audio (optional, defaults to the
+	// provider's configured language or auto-detection)
+	Language string
+}
+
+// Word is a recognized word with its position in the audio.
+type Word struct {
+	Text       string
+	Start      time.Duration // Offset of the word from the start of the audio
+	End        time.Duration
+	Confidence float32 // 0.0 to 1.0, or 0 if the provider does not report it
+}
+
+// Recognition is the final transcript of a complete buffer of audio.
+type Recognition struct {
+	Text       string
+	Language   string  // Detected or requested language
+	Confidence float32 // 0.0 to 1.0, or 0 if the provider does not report it
+	Words      []Word  // Empty if the provider does not report word timings
+}
+
+// Recognizer is implemented by STT providers that can transcribe a complete
+// buffer of audio in a single request, for files and offline jobs. It is
+// optional; use Recognize to transcribe with any provider.
+type Recognizer interface {
+	// Recognize transcribes frames and returns the final transcript.
+	Recognize(ctx context.Context, frames []rtc.AudioFrame, opts RecognizeOptions) (*Recognition, error)
+}
+
+// RecognizerFunc adapts a function to a Recognizer.
+type RecognizerFunc func(ctx context.Context, frames []rtc.AudioFrame, opts RecognizeOptions) (*Recognition, error)
+
+// Recognize calls f.
+func (f RecognizerFunc) Recognize(ctx context.Context, frames []rtc.AudioFrame, opts RecognizeOptions) (*Recognition, error) {
+	return f(ctx, frames, opts)
+}
+
+// Recognize transcribes a complete buffer of audio with provider. Providers
+// that implement Recognizer are called directly; others are streamed the
+// audio, and the best alternatives of their final transcripts are joined.
+func Recognize(ctx context.Context, provider STT, frames []rtc.AudioFrame, opts RecognizeOptions) (*Recognition, error) {
+	if recognizer, ok := provider.(Recognizer); ok {
+		return recognizer.Recognize(ctx, frames, opts)
+	}
+	if len(frames) == 0 {
+		return &Recognition{Language: opts.Language}, nil
+	}
+
+	stream, err := provider.NewStream(ctx, StreamConfig{
+		SampleRate:  frames[0].SampleRate,
+		NumChannels: frames[0].NumChannels,
+		Lang:        opts.Language,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create STT stream: %w", err)
+	}
+
+	// Read events while pushing, since streams may block on a full channel
+	type outcome struct {
+		result *Recognition
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result := &Recognition{Language: opts.Language}
+		var texts []string
+		var confidence float32
+		var scored int
+		var streamErr error
+		for event := range stream.Events() {
+			switch event.Type {
+			case SpeechEventFinal:
+				if event.Text != "" {
+					texts = append(texts, event.Text)
+				}
+				if event.Language != "" {
+					result.Language = event.Language
+				}
+				if len(event.Alternatives) > 0 {
+					best := event.Alternatives[0]
+					result.Words = append(result.Words, best.Words...)
+					if best.Confidence > 0 {
+						confidence += best.Confidence
+						scored++
+					}
+				}
+			case SpeechEventError:
+				if streamErr == nil {
+					streamErr = event.Error
+				}
+			}
+		}
+		result.Text = strings.Join(texts, " ")
+		if scored > 0 {
+			result.Confidence = confidence / float32(scored)
+		}
+		done <- outcome{result, streamErr}
+	}()
+
+	for i, frame := range frames {
+		if err := stream.Push(frame); err != nil {
+			stream.CloseSend()
+			return nil, fmt.Errorf("failed to push audio frame %d: %w", i, err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, fmt.Errorf("failed to close STT stream: %w", err)
+	}
+
+	select {
+	case out := <-done:
+		if out.err != nil {
+			return nil, out.err
+		}
+		return out.result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,56 @@
+package stt
+
+import (
+	"context"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// RetrySTT retries opening streams on an STT provider when it fails with a
+// recoverable error. Errors on an open stream are delivered as error events
+// and are not retried, since the audio already pushed cannot be replayed.
+// Batch recognitions are retried as a whole.
+type RetrySTT struct {
+	stt STT
+	cfg ai.RetryConfig
+}
+
+// NewRetrySTT wraps an STT provider in a RetrySTT.
+func NewRetrySTT(s STT, cfg ai.RetryConfig) *RetrySTT {
+	return &RetrySTT{stt: s, cfg: cfg}
+}
+
+// NewStream opens a stream, retrying recoverable failures.
+func (r *RetrySTT) NewStream(ctx context.Context, cfg StreamConfig) (STTStream, error) {
+	var stream STTStream
+	err := ai.Retry(ctx, r.cfg, func(ctx context.Context) error {
+		var err error
+		stream, err = r.stt.NewStream(ctx, cfg)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// Recognize transcribes a complete buffer of audio, retrying recoverable
+// failures.
+func (r *RetrySTT) Recognize(ctx context.Context, frames []rtc.AudioFrame, opts RecognizeOptions) (*Recognition, error) {
+	var result *Recognition
+	err := ai.Retry(ctx, r.cfg, func(ctx context.Context) error {
+		var err error
+		result, err = Recognize(ctx, r.stt, frames, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Capabilities returns the wrapped provider's capabilities.
+func (r *RetrySTT) Capabilities() STTCapabilities {
+	return r.stt.Capabilities()
+}
@@ -5,6 +5,7 @@ package stt
 
 import (
 	"context"
+	"time"
 
 	"github.com/chriscow/livekit-agents-go/pkg/ai"
 	"github.com/chriscow/livekit-agents-go/pkg/rtc"
@@ -37,6 +38,29 @@ type SpeechEvent struct {
 	Language  string          // Detected or configured language code
 	Timestamp int64           // Event timestamp in milliseconds since epoch
 	Error     error           // Error details (only set for error events)
+
+	// Alternatives are the transcription hypotheses of interim, preflight and
+	// final events, best first. Text and Language repeat the best one.
+	Alternatives []SpeechData
+
+	// Usage is the audio recognized (only set for recognition usage events)
+	Usage *RecognitionUsage
+}
+
+// SpeechData is one transcription hypothesis for a span of audio.
+type SpeechData struct {
+	Text       string
+	Language   string
+	Confidence float32       // 0.0 to 1.0, or 0 if the provider does not report it
+	StartTime  time.Duration // Offset of the transcribed audio from the start of the stream
+	EndTime    time.Duration
+	Words      []Word // Word timings relative to the stream, if the provider reports them
+	SpeakerID  string // Speaker label, if the provider diarizes
+}
+
+// RecognitionUsage reports the audio a provider recognized, for metering.
+type RecognitionUsage struct {
+	AudioDuration time.Duration
 }
 
 // SpeechEventType represents the type of speech recognition event.
@@ -49,6 +73,16 @@ const (
 	SpeechEventFinal
 	// SpeechEventError represents transcription errors
 	SpeechEventError
+	// SpeechEventStartOfSpeech reports that the provider detected the start of speech
+	SpeechEventStartOfSpeech
+	// SpeechEventEndOfSpeech reports that the provider detected the end of speech
+	SpeechEventEndOfSpeech
+	// SpeechEventRecognitionUsage reports the audio recognized since the last usage event
+	SpeechEventRecognitionUsage
+	// SpeechEventPreflightTranscript represents an eager transcript sent when the
+	// provider expects the utterance to be complete. The final transcript that
+	// follows may still differ.
+	SpeechEventPreflightTranscript
 )
 
 // STTCapabilities describes the capabilities of an STT provider.
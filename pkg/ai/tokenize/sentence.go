@@ -0,0 +1,117 @@
+// Package tokenize provides text segmentation helpers used to turn streamed
+// LLM output into units that can be handed to a TTS provider incrementally.
+package tokenize
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultMinSentenceLength is the minimum number of characters a sentence must
+// contain before it is emitted on its own. Shorter fragments ("Hi!", "Ok.") are
+// merged with the following sentence to avoid very small TTS requests.
+const DefaultMinSentenceLength = 8
+
+// abbreviations lists common abbreviations whose trailing period does not end a sentence.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "sr": true, "jr": true,
+	"st": true, "vs": true, "etc": true, "e.g": true, "i.e": true, "inc": true, "ltd": true,
+}
+
+// SentenceTokenizer incrementally splits streamed text into complete sentences.
+// It is not safe for concurrent use.
+type SentenceTokenizer struct {
+	// MinLength is the minimum sentence length in characters (see DefaultMinSentenceLength).
+	MinLength int
+
+	buf strings.Builder
+}
+
+// NewSentenceTokenizer creates a tokenizer using DefaultMinSentenceLength.
+func NewSentenceTokenizer() *SentenceTokenizer {
+	return &SentenceTokenizer{MinLength: DefaultMinSentenceLength}
+}
+
+// Push appends a text delta and returns any sentences that are now complete.
+// A sentence is only considered complete once the character following its
+// terminal punctuation has been seen, so trailing text stays buffered.
+func (t *SentenceTokenizer) Push(delta string) []string {
+	t.buf.WriteString(delta)
+
+	text := t.buf.String()
+	var sentences []string
+	start := 0
+	runes := []rune(text)
+	for i := 0; i < len(runes)-1; i++ {
+		if !isTerminal(runes[i]) {
+			continue
+		}
+		// Absorb closing quotes and brackets that belong to the sentence
+		end := i + 1
+		for end < len(runes) && isClosing(runes[end]) {
+			end++
+		}
+		if end >= len(runes) || !unicode.IsSpace(runes[end]) {
+			continue
+		}
+		candidate := strings.TrimSpace(string(runes[start:end]))
+		if runes[i] == '.' && isAbbreviation(candidate) {
+			continue
+		}
+		if len([]rune(candidate)) < t.MinLength {
+			continue
+		}
+		sentences = append(sentences, candidate)
+		start = end
+	}
+
+	t.buf.Reset()
+	t.buf.WriteString(string(runes[start:]))
+	return sentences
+}
+
+// Flush returns any buffered text as a final sentence and resets the tokenizer.
+// It returns an empty string if nothing but whitespace is buffered.
+func (t *SentenceTokenizer) Flush() string {
+	rest := strings.TrimSpace(t.buf.String())
+	t.buf.Reset()
+	return rest
+}
+
+// SplitSentences splits a complete text into sentences using DefaultMinSentenceLength.
+func SplitSentences(text string) []string {
+	tok := NewSentenceTokenizer()
+	sentences := tok.Push(text)
+	if rest := tok.Flush(); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// isTerminal reports whether r ends a sentence.
+func isTerminal(r rune) bool {
+	switch r {
+	case '.', '!', '?', '。', '！', '？', '…':
+		return true
+	}
+	return false
+}
+
+// isClosing reports whether r is a closing quote or bracket that may follow terminal punctuation.
+func isClosing(r rune) bool {
+	switch r {
+	case '"', '\'', ')', ']', '”', '’', '»':
+		return true
+	}
+	return false
+}
+
+// isAbbreviation reports whether the sentence candidate ends with a known abbreviation.
+func isAbbreviation(candidate string) bool {
+	fields := strings.Fields(candidate)
+	if len(fields) == 0 {
+		return false
+	}
+	last := strings.ToLower(strings.TrimSuffix(fields[len(fields)-1], "."))
+	return abbreviations[last]
+}
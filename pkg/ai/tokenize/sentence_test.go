@@ -0,0 +1,69 @@
+package tokenize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSentenceTokenizer_Push(t *testing.T) {
+	tok := NewSentenceTokenizer()
+
+	var got []string
+	for _, delta := range []string{"Hello there", ", how are", " you today? I am", " fine. Than", "ks!"} {
+		got = append(got, tok.Push(delta)...)
+	}
+
+	want := []string{"Hello there, how are you today?", "I am fine."}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Push() sentences = %q, want %q", got, want)
+	}
+
+	if rest := tok.Flush(); rest != "Thanks!" {
+		t.Errorf("Flush() = %q, want %q", rest, "Thanks!")
+	}
+	if rest := tok.Flush(); rest != "" {
+		t.Errorf("second Flush() = %q, want empty", rest)
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "simple",
+			text: "The weather is nice. Shall we go outside?",
+			want: []string{"The weather is nice.", "Shall we go outside?"},
+		},
+		{
+			name: "short sentences are merged",
+			text: "Ok. Let me check that for you.",
+			want: []string{"Ok. Let me check that for you."},
+		},
+		{
+			name: "abbreviations and decimals",
+			text: "Dr. Smith paid 3.50 dollars. He was happy.",
+			want: []string{"Dr. Smith paid 3.50 dollars.", "He was happy."},
+		},
+		{
+			name: "closing quotes",
+			text: "She said \"good morning.\" Then she left.",
+			want: []string{"She said \"good morning.\"", "Then she left."},
+		},
+		{
+			name: "no terminal punctuation",
+			text: "just a fragment",
+			want: []string{"just a fragment"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitSentences(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitSentences() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package tts
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultCharsPerSecond approximates how many characters of text a TTS voice
+// speaks per second at normal speed. It is used to estimate word timings for
+// providers without native alignment.
+const DefaultCharsPerSecond = 15.0
+
+// WordTiming locates a word of the synthesized text in the audio. Start and
+// End are relative to the start of the audio for the text.
+type WordTiming struct {
+	Word   string
+	Offset int // Byte offset of the word in the text
+	Start  time.Duration
+	End    time.Duration
+}
+
+// EstimateWordTimings estimates word timings for text spoken at a constant
+// rate of charsPerSecond. Spaces and punctuation take time like any other
+// character, which roughly accounts for pauses.
+func EstimateWordTimings(text string, charsPerSecond float64) []WordTiming {
+	if charsPerSecond <= 0 {
+		charsPerSecond = DefaultCharsPerSecond
+	}
+	at := func(chars int) time.Duration {
+		return time.Duration(float64(chars) / charsPerSecond * float64(time.Second))
+	}
+
+	var timings []WordTiming
+	chars, start, startChars := 0, -1, 0
+	for offset, r := range text + " " {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				timings = append(timings, WordTiming{
+					Word:   text[start:offset],
+					Offset: start,
+					Start:  at(startChars),
+					End:    at(chars),
+				})
+				start = -1
+			}
+		} else if start < 0 {
+			start, startChars = offset, chars
+		}
+		chars++
+	}
+	return timings
+}
+
+// SpokenText returns the prefix of text up to the last word that finished
+// within d of audio. Words cut off part way through are not included.
+func SpokenText(text string, timings []WordTiming, d time.Duration) string {
+	end := 0
+	for _, timing := range timings {
+		if timing.End > d {
+			break
+		}
+		// Stop at timings that do not match the text
+		wordEnd := timing.Offset + len(timing.Word)
+		if timing.Offset < end || wordEnd > len(text) || text[timing.Offset:wordEnd] != timing.Word {
+			break
+		}
+		end = wordEnd
+	}
+	return strings.TrimSpace(text[:end])
+}
@@ -0,0 +1,72 @@
+package tts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateWordTimings(t *testing.T) {
+	text := "Hello  big world."
+	timings := EstimateWordTimings(text, 10)
+
+	want := []WordTiming{
+		{Word: "Hello", Offset: 0, Start: 0, End: 500 * time.Millisecond},
+		{Word: "big", Offset: 7, Start: 700 * time.Millisecond, End: time.Second},
+		{Word: "world.", Offset: 11, Start: 1100 * time.Millisecond, End: 1700 * time.Millisecond},
+	}
+	if len(timings) != len(want) {
+		t.Fatalf("expected %d timings, got %d: %+v", len(want), len(timings), timings)
+	}
+	for i, timing := range timings {
+		if timing != want[i] {
+			t.Errorf("timing %d: expected %+v, got %+v", i, want[i], timing)
+		}
+	}
+}
+
+func TestEstimateWordTimings_DefaultRate(t *testing.T) {
+	timings := EstimateWordTimings("fifteen chars!!", 0)
+	if len(timings) != 2 {
+		t.Fatalf("expected 2 timings, got %d", len(timings))
+	}
+	if timings[1].End != time.Second {
+		t.Errorf("expected text to end after 1s at the default rate, got %v", timings[1].End)
+	}
+}
+
+func TestSpokenText(t *testing.T) {
+	text := "one two three"
+	timings := []WordTiming{
+		{Word: "one", Offset: 0, Start: 0, End: 300 * time.Millisecond},
+		{Word: "two", Offset: 4, Start: 400 * time.Millisecond, End: 700 * time.Millisecond},
+		{Word: "three", Offset: 8, Start: 800 * time.Millisecond, End: 1200 * time.Millisecond},
+	}
+
+	tests := []struct {
+		played time.Duration
+		want   string
+	}{
+		{0, ""},
+		{300 * time.Millisecond, "one"},
+		{750 * time.Millisecond, "one two"},
+		{time.Second, "one two"},
+		{2 * time.Second, "one two three"},
+	}
+	for _, tt := range tests {
+		if got := SpokenText(text, timings, tt.played); got != tt.want {
+			t.Errorf("SpokenText(%v) = %q, want %q", tt.played, got, tt.want)
+		}
+	}
+}
+
+func TestSpokenText_StopsAtMismatchedTimings(t *testing.T) {
+	text := "one two three"
+	timings := []WordTiming{
+		{Word: "one", Offset: 0, End: 100 * time.Millisecond},
+		{Word: "too", Offset: 4, End: 200 * time.Millisecond},
+		{Word: "three", Offset: 8, End: 300 * time.Millisecond},
+	}
+	if got := SpokenText(text, timings, time.Second); got != "one" {
+		t.Errorf("expected text up to the mismatched timing, got %q", got)
+	}
+}
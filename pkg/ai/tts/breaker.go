@@ -0,0 +1,60 @@
+package tts
+
+import (
+	"context"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// BreakerTTS guards a TTS provider with a circuit breaker. While the breaker
+// is open, requests fail immediately with ai.ErrCircuitOpen instead of waiting
+// on a provider that keeps failing. A synthesis session counts as failed if it
+// fails to start or stops early.
+type BreakerTTS struct {
+	tts     TTS
+	breaker *ai.CircuitBreaker
+}
+
+// NewBreakerTTS wraps a TTS provider in a BreakerTTS.
+func NewBreakerTTS(t TTS, cfg ai.BreakerConfig) *BreakerTTS {
+	return &BreakerTTS{tts: t, breaker: ai.NewCircuitBreaker(cfg)}
+}
+
+// Breaker returns the circuit breaker guarding the provider.
+func (b *BreakerTTS) Breaker() *ai.CircuitBreaker {
+	return b.breaker
+}
+
+// Synthesize starts synthesizing req if the breaker allows it. Only failures
+// to start count, use SynthesizeStream to also count those part way through.
+func (b *BreakerTTS) Synthesize(ctx context.Context, req SynthesizeRequest) (<-chan rtc.AudioFrame, error) {
+	if err := b.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	frames, err := b.tts.Synthesize(ctx, req)
+	b.breaker.Record(err)
+	if err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
+
+// SynthesizeStream opens a synthesis session if the breaker allows it.
+// Providers that cannot stream are adapted with a StreamAdapter.
+func (b *BreakerTTS) SynthesizeStream(ctx context.Context, req SynthesizeRequest) (SynthesizeStream, error) {
+	if err := b.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	stream, err := AsStreaming(b.tts).SynthesizeStream(ctx, req)
+	if err != nil {
+		b.breaker.Record(err)
+		return nil, err
+	}
+	return reportStream(ctx, stream, b.breaker.Record), nil
+}
+
+// Capabilities returns the wrapped provider's capabilities.
+func (b *BreakerTTS) Capabilities() TTSCapabilities {
+	return b.tts.Capabilities()
+}
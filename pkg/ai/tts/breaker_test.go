@@ -0,0 +1,28 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+)
+
+func TestBreakerTTS_Stream(t *testing.T) {
+	provider := &failingTTS{err: ai.NewRecoverableError(errors.New("reset"), "connection reset")}
+	breaker := NewBreakerTTS(provider, ai.BreakerConfig{Name: "test-tts", MinRequests: 1, CoolDown: time.Hour})
+
+	timings, res := synthesizeText(t, breaker, "Hi there.")
+	// The provider has no alignment, so its timings are estimated
+	if len(timings) != 2 {
+		t.Errorf("expected 2 estimated timings, got %+v", timings)
+	}
+	if !ai.IsRecoverable(res.Err) {
+		t.Errorf("expected the provider's error, got %v", res.Err)
+	}
+
+	if _, err := breaker.SynthesizeStream(context.Background(), SynthesizeRequest{}); !errors.Is(err, ai.ErrCircuitOpen) {
+		t.Errorf("expected the failure to open the breaker, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package tts
+
+import (
+	"context"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// healthCheckText is synthesized to check whether a provider has recovered.
+const healthCheckText = "Hello."
+
+// FallbackAdapter synthesizes with the first of several TTS providers that is
+// in use, failing over to the next one when a provider fails fatally or
+// repeatedly. Providers taken out of use are health checked in the background
+// and used again once they recover. Requests are passed to every provider
+// unchanged, so voices should be left empty or be valid for all of them.
+type FallbackAdapter struct {
+	failover *ai.Failover[TTS]
+}
+
+// NewFallbackAdapter creates a FallbackAdapter for providers, in order of
+// preference. At least one provider is required.
+func NewFallbackAdapter(providers []TTS, cfg ai.FallbackConfig) *FallbackAdapter {
+	return &FallbackAdapter{failover: ai.NewFailover(providers, checkTTS, cfg)}
+}
+
+// checkTTS synthesizes a short phrase to check that t is working.
+func checkTTS(ctx context.Context, t TTS) error {
+	stream, err := AsStreaming(t).SynthesizeStream(ctx, SynthesizeRequest{})
+	if err != nil {
+		return err
+	}
+	stream.PushText(healthCheckText)
+	stream.Close()
+	for range stream.Frames() {
+	}
+	return stream.Result().Err
+}
+
+// Synthesize starts synthesizing req with the first provider that accepts it.
+func (f *FallbackAdapter) Synthesize(ctx context.Context, req SynthesizeRequest) (<-chan rtc.AudioFrame, error) {
+	var frames <-chan rtc.AudioFrame
+	_, err := f.failover.Do(ctx, func(t TTS) error {
+		var err error
+		frames, err = t.Synthesize(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
+
+// SynthesizeStream opens a synthesis session with the first provider that
+// accepts it. A failure part way through counts against that provider.
+// Providers that cannot stream are adapted with a StreamAdapter.
+func (f *FallbackAdapter) SynthesizeStream(ctx context.Context, req SynthesizeRequest) (SynthesizeStream, error) {
+	var stream SynthesizeStream
+	i, err := f.failover.Do(ctx, func(t TTS) error {
+		var err error
+		stream, err = AsStreaming(t).SynthesizeStream(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reportStream(ctx, stream, func(err error) { f.failover.Report(i, err) }), nil
+}
+
+// Capabilities returns the capabilities of the primary provider.
+func (f *FallbackAdapter) Capabilities() TTSCapabilities {
+	return f.failover.Primary().Capabilities()
+}
+
+// Close stops health checks of providers out of use.
+func (f *FallbackAdapter) Close() error {
+	f.failover.Close()
+	return nil
+}
+
+// reportingStream forwards the frames of a stream so that its outcome can be
+// reported once they have all been delivered.
+type reportingStream struct {
+	SynthesizeStream
+	frames chan rtc.AudioFrame
+}
+
+// reportStream wraps stream to call report with its error once all its
+// frames have been delivered.
+func reportStream(ctx context.Context, stream SynthesizeStream, report func(error)) SynthesizeStream {
+	reported := &reportingStream{SynthesizeStream: stream, frames: make(chan rtc.AudioFrame, 10)}
+	go func() {
+		defer close(reported.frames)
+		for frame := range stream.Frames() {
+			// Frames produced after cancellation are discarded
+			select {
+			case reported.frames <- frame:
+			case <-ctx.Done():
+			}
+		}
+		report(stream.Result().Err)
+	}()
+	return reported
+}
+
+func (s *reportingStream) Frames() <-chan rtc.AudioFrame {
+	return s.frames
+}
@@ -0,0 +1,122 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+func TestRetryTTS_RetriesStart(t *testing.T) {
+	provider := &textTTS{failOn: "Hello."}
+	retry := NewRetryTTS(provider, ai.RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond})
+
+	// textTTS errors are unclassified, so they are not retried
+	if _, err := retry.Synthesize(context.Background(), SynthesizeRequest{Text: "Hello."}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(provider.requests) != 1 {
+		t.Errorf("expected 1 request for an unclassified error, got %d", len(provider.requests))
+	}
+
+	if _, err := retry.Synthesize(context.Background(), SynthesizeRequest{Text: "Fine."}); err != nil {
+		t.Errorf("expected synthesis to succeed, got %v", err)
+	}
+}
+
+// alignedTextTTS is a textTTS whose sessions report a single one second
+// timing for each sentence.
+type alignedTextTTS struct {
+	textTTS
+}
+
+func (a *alignedTextTTS) SynthesizeStream(ctx context.Context, req SynthesizeRequest) (SynthesizeStream, error) {
+	return NewSentenceStream(ctx, req, a.synthesizeSentence), nil
+}
+
+func (a *alignedTextTTS) synthesizeSentence(ctx context.Context, req SynthesizeRequest, frames chan<- rtc.AudioFrame, words chan<- WordTiming) error {
+	words <- WordTiming{Word: req.Text, End: time.Second}
+	frames <- rtc.AudioFrame{Data: []byte(req.Text), SampleRate: 16000, NumChannels: 1}
+	return nil
+}
+
+// synthesizeText synthesizes text in a session of t with word timings and
+// returns the timings and the result.
+func synthesizeText(t *testing.T, synthesizer StreamingTTS, text string) ([]WordTiming, SynthesisResult) {
+	t.Helper()
+	stream, err := synthesizer.SynthesizeStream(context.Background(), SynthesizeRequest{WordTimings: true})
+	if err != nil {
+		t.Fatalf("SynthesizeStream failed: %v", err)
+	}
+	stream.PushText(text)
+	stream.Close()
+
+	var timings []WordTiming
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for word := range stream.Words() {
+			timings = append(timings, word)
+		}
+	}()
+	collect(t, stream.Frames())
+	<-done
+	return timings, stream.Result()
+}
+
+func TestRetryTTS_Stream(t *testing.T) {
+	retry := NewRetryTTS(&alignedTextTTS{}, ai.RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond})
+	timings, res := synthesizeText(t, retry, "Hi.")
+	if len(timings) != 1 || timings[0].End != time.Second {
+		t.Errorf("expected the provider's timing, got %+v", timings)
+	}
+	if res.Err != nil || res.Characters != 3 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+
+	retry = NewRetryTTS(&failingTTS{err: ai.NewFatalError(errors.New("quota"), "quota exceeded")}, ai.RetryConfig{MaxRetries: 2})
+	if _, res := synthesizeText(t, retry, "Hi."); !ai.IsFatal(res.Err) {
+		t.Errorf("expected the provider's error, got %v", res.Err)
+	}
+}
+
+func TestFallbackAdapter_FailsOverOnResultErrors(t *testing.T) {
+	primary := &failingTTS{err: ai.NewRecoverableError(errors.New("reset"), "connection reset")}
+	secondary := &textTTS{}
+	adapter := NewFallbackAdapter([]TTS{primary, secondary}, ai.FallbackConfig{MaxFailures: 2, HealthCheckInterval: time.Hour})
+	defer adapter.Close()
+
+	for i := 0; i < 3; i++ {
+		timings, res := synthesizeText(t, adapter, "Hi.")
+		if i < 2 && !ai.IsRecoverable(res.Err) {
+			t.Errorf("synthesis %d: expected the primary's error, got %v", i, res.Err)
+		}
+		if i == 2 && res.Err != nil {
+			t.Errorf("expected the secondary to be used after 2 failures, got %v", res.Err)
+		}
+		// Neither provider has alignment, so the timings are estimated
+		if len(timings) != 1 || timings[0].End != EstimateWordTimings("Hi.", 0)[0].End {
+			t.Errorf("synthesis %d: expected an estimated timing, got %+v", i, timings)
+		}
+	}
+	if len(secondary.requests) != 1 {
+		t.Errorf("expected 1 request to the secondary, got %d", len(secondary.requests))
+	}
+}
+
+func TestFallbackAdapter_Stream(t *testing.T) {
+	adapter := NewFallbackAdapter([]TTS{&alignedTextTTS{}}, ai.FallbackConfig{HealthCheckInterval: time.Hour})
+	defer adapter.Close()
+	if timings, _ := synthesizeText(t, adapter, "Hello."); len(timings) != 1 || timings[0].End != time.Second {
+		t.Errorf("expected the provider's timing, got %+v", timings)
+	}
+
+	adapter = NewFallbackAdapter([]TTS{&textTTS{failOn: "Hi."}}, ai.FallbackConfig{HealthCheckInterval: time.Hour})
+	defer adapter.Close()
+	if _, res := synthesizeText(t, adapter, "Hi. "); res.Err == nil {
+		t.Error("expected the stream's synthesis error")
+	}
+}
@@ -0,0 +1,10 @@
+package tts
+
+import "time"
+
+// SynthesisResult describes how a synthesis ended.
+type SynthesisResult struct {
+	Characters    int           // Characters of the text synthesized
+	AudioDuration time.Duration // Duration of the audio produced
+	Err           error         // Why synthesis stopped early, nil if it completed
+}
@@ -0,0 +1,83 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// failingTTS is a StreamingTTS whose sentences emit one frame and then fail with err.
+type failingTTS struct {
+	textTTS
+	err error
+}
+
+func (f *failingTTS) SynthesizeStream(ctx context.Context, req SynthesizeRequest) (SynthesizeStream, error) {
+	return NewSentenceStream(ctx, req, WithEstimatedTimings(f.synthesizeSentence)), nil
+}
+
+func (f *failingTTS) synthesizeSentence(ctx context.Context, req SynthesizeRequest, frames chan<- rtc.AudioFrame, words chan<- WordTiming) error {
+	select {
+	case frames <- rtc.AudioFrame{Data: []byte(req.Text), SampleRate: 16000, NumChannels: 1}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return f.err
+}
+
+func TestSentenceStream_Result(t *testing.T) {
+	stream, _ := NewStreamAdapter(&textTTS{}).SynthesizeStream(context.Background(), SynthesizeRequest{})
+	stream.PushText("Héllo there. Bye now.")
+	stream.Close()
+	if got := collect(t, stream.Frames()); len(got) != 2 {
+		t.Fatalf("expected a frame per sentence, got %q", got)
+	}
+
+	// 13 and 8 bytes of audio are 6 and 4 samples at 16kHz
+	res := stream.Result()
+	if res.Err != nil || res.Characters != 20 || res.AudioDuration != 10*time.Second/16000 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+}
+
+func TestSentenceStream_ResultError(t *testing.T) {
+	provider := &failingTTS{err: ai.NewRecoverableError(errors.New("connection reset"), "read failed")}
+	stream, _ := provider.SynthesizeStream(context.Background(), SynthesizeRequest{})
+	stream.PushText("First sentence. Second sentence. ")
+	stream.Close()
+
+	if got := collect(t, stream.Frames()); len(got) != 1 {
+		t.Fatalf("expected the frame sent before the failure, got %q", got)
+	}
+	res := stream.Result()
+	if !ai.IsRecoverable(res.Err) {
+		t.Errorf("expected the provider's recoverable error, got %v", res.Err)
+	}
+	if res.Characters != 0 || res.AudioDuration != 7*time.Second/16000 {
+		t.Errorf("expected only the delivered audio in the result, got %+v", res)
+	}
+}
+
+func TestStreamAdapter_StopsOnResultError(t *testing.T) {
+	provider := &failingTTS{err: ai.NewFatalError(errors.New("quota"), "quota exceeded")}
+	stream, err := NewStreamAdapter(provider).SynthesizeStream(context.Background(), SynthesizeRequest{})
+	if err != nil {
+		t.Fatalf("SynthesizeStream failed: %v", err)
+	}
+
+	if err := stream.PushText("First sentence. Second sentence. "); err != nil {
+		t.Fatalf("PushText failed: %v", err)
+	}
+	stream.Close()
+
+	if got := collect(t, stream.Frames()); len(got) != 1 || got[0] != "First sentence." {
+		t.Errorf("expected only the first sentence's audio, got %q", got)
+	}
+	if err := stream.Result().Err; !ai.IsFatal(err) {
+		t.Errorf("expected the fatal synthesis error, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package tts
+
+import (
+	"context"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// RetryTTS retries requests to a TTS provider that fail with a recoverable
+// error before any audio was produced. Failures part way through a synthesis
+// are reported in the result of its stream and are not retried, since part of
+// the audio may already have been played.
+type RetryTTS struct {
+	tts TTS
+	cfg ai.RetryConfig
+}
+
+// NewRetryTTS wraps a TTS provider in a RetryTTS.
+func NewRetryTTS(t TTS, cfg ai.RetryConfig) *RetryTTS {
+	return &RetryTTS{tts: t, cfg: cfg}
+}
+
+// Synthesize starts synthesizing req, retrying recoverable failures.
+func (r *RetryTTS) Synthesize(ctx context.Context, req SynthesizeRequest) (<-chan rtc.AudioFrame, error) {
+	var frames <-chan rtc.AudioFrame
+	err := ai.Retry(ctx, r.cfg, func(ctx context.Context) error {
+		var err error
+		frames, err = r.tts.Synthesize(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
+
+// SynthesizeStream opens a synthesis session, retrying recoverable failures.
+// Providers that cannot stream are adapted with a StreamAdapter.
+func (r *RetryTTS) SynthesizeStream(ctx context.Context, req SynthesizeRequest) (SynthesizeStream, error) {
+	var stream SynthesizeStream
+	err := ai.Retry(ctx, r.cfg, func(ctx context.Context) error {
+		var err error
+		stream, err = AsStreaming(r.tts).SynthesizeStream(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// Capabilities returns the wrapped provider's capabilities.
+func (r *RetryTTS) Capabilities() TTSCapabilities {
+	return r.tts.Capabilities()
+}
@@ -0,0 +1,405 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/tokenize"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// ErrStreamClosed is returned when text is pushed to a closed synthesis stream.
+var ErrStreamClosed = errors.New("synthesis stream closed")
+
+// StreamAdapter makes a non-streaming TTS usable as a StreamingTTS. Pushed text
+// is split into sentences, and each sentence is synthesized with Synthesize as
+// soon as it is complete. Audio is delivered in sentence order.
+type StreamAdapter struct {
+	tts TTS
+}
+
+// NewStreamAdapter wraps a TTS provider in a StreamAdapter.
+func NewStreamAdapter(t TTS) *StreamAdapter {
+	return &StreamAdapter{tts: t}
+}
+
+// AsStreaming returns t itself if it supports streaming synthesis, otherwise a
+// StreamAdapter around it.
+func AsStreaming(t TTS) StreamingTTS {
+	if s, ok := t.(StreamingTTS); ok && t.Capabilities().Streaming {
+		return s
+	}
+	return NewStreamAdapter(t)
+}
+
+// Synthesize passes the request to the wrapped provider.
+func (a *StreamAdapter) Synthesize(ctx context.Context, req SynthesizeRequest) (<-chan rtc.AudioFrame, error) {
+	return a.tts.Synthesize(ctx, req)
+}
+
+// Capabilities returns the wrapped provider's capabilities with streaming enabled.
+func (a *StreamAdapter) Capabilities() TTSCapabilities {
+	caps := a.tts.Capabilities()
+	caps.Streaming = true
+	return caps
+}
+
+// SynthesizeStream opens a session that synthesizes pushed text sentence by
+// sentence. If synthesizing a sentence fails, including part way through for
+// providers that implement StreamingTTS, the stream stops: its frame channel
+// is closed and later calls return the error.
+func (a *StreamAdapter) SynthesizeStream(ctx context.Context, req SynthesizeRequest) (SynthesizeStream, error) {
+	if streaming, ok := a.tts.(StreamingTTS); ok {
+		return NewSentenceStream(ctx, req, sessionSentence(streaming)), nil
+	}
+	return NewSentenceStream(ctx, req, WithEstimatedTimings(a.synthesizeSentence)), nil
+}
+
+// synthesizeSentence synthesizes a sentence with the wrapped provider.
+func (a *StreamAdapter) synthesizeSentence(ctx context.Context, req SynthesizeRequest, frames chan<- rtc.AudioFrame, words chan<- WordTiming) error {
+	audio, err := a.tts.Synthesize(ctx, req)
+	if err != nil {
+		return err
+	}
+	return forward(ctx, audio, nil, frames, words)
+}
+
+// sessionSentence returns a SentenceFunc that synthesizes each sentence in a
+// session of its own, for providers that do not accept text incrementally.
+func sessionSentence(t StreamingTTS) SentenceFunc {
+	return func(ctx context.Context, req SynthesizeRequest, frames chan<- rtc.AudioFrame, words chan<- WordTiming) error {
+		stream, err := t.SynthesizeStream(ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := stream.PushText(req.Text); err != nil {
+			stream.Close()
+			return err
+		}
+		if err := stream.Close(); err != nil {
+			return err
+		}
+		if err := forward(ctx, stream.Frames(), stream.Words(), frames, words); err != nil {
+			return err
+		}
+		return stream.Result().Err
+	}
+}
+
+// forward copies frames and word timings to the output channels until the
+// input channels are closed. A nil input channel counts as closed.
+func forward(ctx context.Context, inFrames <-chan rtc.AudioFrame, inWords <-chan WordTiming, frames chan<- rtc.AudioFrame, words chan<- WordTiming) error {
+	for inFrames != nil || inWords != nil {
+		select {
+		case frame, ok := <-inFrames:
+			if !ok {
+				inFrames = nil
+				continue
+			}
+			select {
+			case frames <- frame:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case word, ok := <-inWords:
+			if !ok {
+				inWords = nil
+				continue
+			}
+			select {
+			case words <- word:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// WithEstimatedTimings wraps the SentenceFunc of a provider without native
+// alignment so that it reports word timings estimated from the request speed.
+func WithEstimatedTimings(synthesize SentenceFunc) SentenceFunc {
+	return func(ctx context.Context, req SynthesizeRequest, frames chan<- rtc.AudioFrame, words chan<- WordTiming) error {
+		if req.WordTimings {
+			charsPerSecond := DefaultCharsPerSecond
+			if req.Speed > 0 {
+				charsPerSecond *= float64(req.Speed)
+			}
+			for _, timing := range EstimateWordTimings(req.Text, charsPerSecond) {
+				select {
+				case words <- timing:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+		return synthesize(ctx, req, frames, words)
+	}
+}
+
+// SentenceFunc synthesizes a single sentence, req.Text, sending its audio to
+// frames and, when req.WordTimings is set, the timing of each word relative to
+// the sentence to words. Sends must give up once ctx is done.
+type SentenceFunc func(ctx context.Context, req SynthesizeRequest, frames chan<- rtc.AudioFrame, words chan<- WordTiming) error
+
+// NewSentenceStream returns a SynthesizeStream that splits pushed text into
+// sentences and synthesizes each with synthesize as soon as it is complete.
+// Audio is delivered in sentence order and word timings are moved to the
+// position of their sentence in the stream. Providers without a streaming API
+// use it to implement StreamingTTS, wrapping synthesize with
+// WithEstimatedTimings if they cannot report timings themselves.
+func NewSentenceStream(ctx context.Context, req SynthesizeRequest, synthesize SentenceFunc) SynthesizeStream {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &sentenceStream{
+		synthesize: synthesize,
+		ctx:        ctx,
+		cancel:     cancel,
+		req:        req,
+		tokenizer:  tokenize.NewSentenceTokenizer(),
+		signal:     make(chan struct{}, 1),
+		frames:     make(chan rtc.AudioFrame, 10),
+		words:      make(chan WordTiming, 10),
+	}
+	go s.run()
+	return s
+}
+
+// sentence is a queued sentence and its byte offset in the pushed text.
+type sentence struct {
+	text   string
+	offset int
+}
+
+// sentenceStream is the SynthesizeStream returned by NewSentenceStream.
+type sentenceStream struct {
+	synthesize SentenceFunc
+	ctx        context.Context
+	cancel     context.CancelFunc
+	req        SynthesizeRequest
+
+	mu        sync.Mutex
+	tokenizer *tokenize.SentenceTokenizer
+	text      string     // all text pushed so far
+	located   int        // end of the last sentence found in text
+	queue     []sentence // sentences waiting for synthesis
+	closed    bool
+	result    SynthesisResult
+	err       error
+
+	signal chan struct{}
+	frames chan rtc.AudioFrame
+	words  chan WordTiming
+}
+
+func (s *sentenceStream) PushText(text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOpen(); err != nil {
+		return err
+	}
+	s.text += text
+	s.enqueue(s.tokenizer.Push(text)...)
+	return nil
+}
+
+func (s *sentenceStream) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOpen(); err != nil {
+		return err
+	}
+	s.enqueue(s.tokenizer.Flush())
+	return nil
+}
+
+func (s *sentenceStream) Frames() <-chan rtc.AudioFrame {
+	return s.frames
+}
+
+func (s *sentenceStream) Words() <-chan WordTiming {
+	return s.words
+}
+
+func (s *sentenceStream) Result() SynthesisResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := s.result
+	result.Err = s.err
+	return result
+}
+
+func (s *sentenceStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return s.err
+	}
+	s.enqueue(s.tokenizer.Flush())
+	s.closed = true
+	s.notify()
+	return s.err
+}
+
+// checkOpen returns the reason text can no longer be pushed. Callers hold mu.
+func (s *sentenceStream) checkOpen() error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.closed {
+		return ErrStreamClosed
+	}
+	return nil
+}
+
+// enqueue adds non-empty sentences to the queue, locating each in the pushed
+// text. Callers hold mu.
+func (s *sentenceStream) enqueue(sentences ...string) {
+	for _, text := range sentences {
+		if text == "" {
+			continue
+		}
+		offset := s.located
+		if i := strings.Index(s.text[s.located:], text); i >= 0 {
+			offset += i
+			s.located = offset + len(text)
+		}
+		s.queue = append(s.queue, sentence{text: text, offset: offset})
+	}
+	if len(s.queue) > 0 {
+		s.notify()
+	}
+}
+
+// notify wakes the synthesis loop without blocking.
+func (s *sentenceStream) notify() {
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the next queued sentence, waiting for one to be pushed. It
+// returns false once the stream is closed and drained, or the context is done
+// in which case the context error is returned as well.
+func (s *sentenceStream) next() (sentence, bool, error) {
+	for {
+		s.mu.Lock()
+		if len(s.queue) > 0 {
+			next := s.queue[0]
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+			return next, true, nil
+		}
+		closed := s.closed
+		s.mu.Unlock()
+		if closed {
+			return sentence{}, false, nil
+		}
+
+		select {
+		case <-s.signal:
+		case <-s.ctx.Done():
+			return sentence{}, false, s.ctx.Err()
+		}
+	}
+}
+
+// run synthesizes queued sentences in order until the stream is closed.
+func (s *sentenceStream) run() {
+	defer close(s.frames)
+	defer close(s.words)
+	defer s.cancel()
+
+	var produced time.Duration // audio delivered so far
+	for {
+		next, ok, err := s.next()
+		if !ok {
+			if err != nil {
+				s.fail(err)
+			}
+			return
+		}
+		if err := s.synthesizeNext(next, &produced); err != nil {
+			s.fail(err)
+			return
+		}
+		s.record(utf8.RuneCountInString(next.text), 0)
+	}
+}
+
+// synthesizeNext synthesizes a sentence whose audio starts after produced,
+// forwarding its frames and word timings to the stream.
+func (s *sentenceStream) synthesizeNext(next sentence, produced *time.Duration) error {
+	req := s.req
+	req.Text = next.text
+	frames := make(chan rtc.AudioFrame, 10)
+	words := make(chan WordTiming, 10)
+	done := make(chan error, 1)
+	go func() {
+		defer close(frames)
+		defer close(words)
+		done <- s.synthesize(s.ctx, req, frames, words)
+	}()
+
+	start := *produced
+	for frames != nil || words != nil {
+		select {
+		case frame, ok := <-frames:
+			if !ok {
+				frames = nil
+				continue
+			}
+			select {
+			case s.frames <- frame:
+				*produced += frame.Duration()
+				s.record(0, frame.Duration())
+			case <-s.ctx.Done():
+				return s.ctx.Err()
+			}
+		case word, ok := <-words:
+			if !ok {
+				words = nil
+				continue
+			}
+			if !s.req.WordTimings {
+				continue
+			}
+			word.Offset += next.offset
+			word.Start += start
+			word.End += start
+			select {
+			case s.words <- word:
+			case <-s.ctx.Done():
+				return s.ctx.Err()
+			}
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+	if err := <-done; err != nil {
+		return fmt.Errorf("failed to synthesize %q: %w", next.text, err)
+	}
+	return nil
+}
+
+// record adds synthesized characters and delivered audio to the result.
+func (s *sentenceStream) record(characters int, audio time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.result.Characters += characters
+	s.result.AudioDuration += audio
+}
+
+// fail records the error that stopped the stream.
+func (s *sentenceStream) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
@@ -0,0 +1,203 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// textTTS emits one frame per request carrying the request's text, and fails
+// requests for the text in failOn.
+type textTTS struct {
+	mu       sync.Mutex
+	requests []SynthesizeRequest
+	failOn   string
+}
+
+func (t *textTTS) Synthesize(ctx context.Context, req SynthesizeRequest) (<-chan rtc.AudioFrame, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req)
+	t.mu.Unlock()
+	if req.Text == t.failOn {
+		return nil, errors.New("synthesis failed")
+	}
+
+	frames := make(chan rtc.AudioFrame, 1)
+	frames <- rtc.AudioFrame{Data: []byte(req.Text), SampleRate: 16000, NumChannels: 1}
+	close(frames)
+	return frames, nil
+}
+
+func (t *textTTS) Capabilities() TTSCapabilities {
+	return TTSCapabilities{SampleRates: []int{16000}}
+}
+
+// collect reads frames until the channel is closed.
+func collect(t *testing.T, frames <-chan rtc.AudioFrame) []string {
+	t.Helper()
+	var texts []string
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case frame, ok := <-frames:
+			if !ok {
+				return texts
+			}
+			texts = append(texts, string(frame.Data))
+		case <-timeout:
+			t.Fatal("timed out waiting for frames")
+		}
+	}
+}
+
+func TestStreamAdapter_SynthesizesSentences(t *testing.T) {
+	provider := &textTTS{}
+	adapter := NewStreamAdapter(provider)
+	if !adapter.Capabilities().Streaming {
+		t.Error("expected the adapter to report streaming support")
+	}
+
+	stream, err := adapter.SynthesizeStream(context.Background(), SynthesizeRequest{Text: "ignored", Voice: "nova"})
+	if err != nil {
+		t.Fatalf("SynthesizeStream failed: %v", err)
+	}
+	for _, fragment := range []string{"Hello the", "re, how are you? I am", " fine today. And you"} {
+		if err := stream.PushText(fragment); err != nil {
+			t.Fatalf("PushText failed: %v", err)
+		}
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	got := collect(t, stream.Frames())
+	want := []string{"Hello there, how are you?", "I am fine today.", "And you"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sentence %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+	for _, req := range provider.requests {
+		if req.Voice != "nova" {
+			t.Errorf("expected the stream's voice on every request, got %+v", req)
+		}
+	}
+
+	if err := stream.PushText("More."); !errors.Is(err, ErrStreamClosed) {
+		t.Errorf("expected ErrStreamClosed after Close, got %v", err)
+	}
+}
+
+func TestStreamAdapter_WordTimings(t *testing.T) {
+	stream, _ := NewStreamAdapter(&textTTS{}).SynthesizeStream(context.Background(), SynthesizeRequest{WordTimings: true})
+	stream.PushText("One two. Thr")
+	stream.PushText("ee four.")
+	stream.Close()
+	collect(t, stream.Frames())
+
+	var words []WordTiming
+	for word := range stream.Words() {
+		words = append(words, word)
+	}
+	offsets := []int{0, 4, 9, 15}
+	if len(words) != len(offsets) {
+		t.Fatalf("expected %d word timings, got %+v", len(offsets), words)
+	}
+	for i, word := range words {
+		if word.Offset != offsets[i] {
+			t.Errorf("word %q: expected offset %d, got %d", word.Word, offsets[i], word.Offset)
+		}
+	}
+	// The second sentence starts after the audio of the first, 4 samples at 16kHz
+	if first := 250 * time.Microsecond; words[2].Start != first {
+		t.Errorf("expected the second sentence to start at %v, got %v", first, words[2].Start)
+	}
+
+	stream, _ = NewStreamAdapter(&textTTS{}).SynthesizeStream(context.Background(), SynthesizeRequest{})
+	stream.PushText("One two.")
+	stream.Close()
+	collect(t, stream.Frames())
+	if word, ok := <-stream.Words(); ok {
+		t.Errorf("expected no timings unless requested, got %+v", word)
+	}
+}
+
+func TestStreamAdapter_Flush(t *testing.T) {
+	stream, _ := NewStreamAdapter(&textTTS{}).SynthesizeStream(context.Background(), SynthesizeRequest{})
+	defer stream.Close()
+
+	// Text without terminal punctuation is only synthesized once flushed
+	stream.PushText("Just a moment")
+	select {
+	case frame := <-stream.Frames():
+		t.Fatalf("unexpected audio before flush: %q", frame.Data)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	stream.Flush()
+	select {
+	case frame := <-stream.Frames():
+		if string(frame.Data) != "Just a moment" {
+			t.Errorf("expected the flushed text, got %q", frame.Data)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for flushed audio")
+	}
+}
+
+func TestStreamAdapter_Error(t *testing.T) {
+	stream, _ := NewStreamAdapter(&textTTS{failOn: "Second sentence."}).SynthesizeStream(context.Background(), SynthesizeRequest{})
+
+	stream.PushText("First sentence. Second sentence. Third sentence.")
+	got := collect(t, stream.Frames())
+	if len(got) != 1 || got[0] != "First sentence." {
+		t.Errorf("expected audio up to the failure, got %q", got)
+	}
+	if err := stream.PushText("More."); err == nil || errors.Is(err, ErrStreamClosed) {
+		t.Errorf("expected the synthesis error, got %v", err)
+	}
+	if err := stream.Close(); err == nil {
+		t.Error("expected Close to report the synthesis error")
+	}
+}
+
+func TestStreamAdapter_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, _ := NewStreamAdapter(&textTTS{}).SynthesizeStream(ctx, SynthesizeRequest{})
+
+	cancel()
+	collect(t, stream.Frames())
+	if err := stream.Result().Err; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancellation in the result, got %v", err)
+	}
+}
+
+// nativeTTS reports streaming support and implements StreamingTTS itself.
+type nativeTTS struct {
+	textTTS
+}
+
+func (n *nativeTTS) Capabilities() TTSCapabilities {
+	return TTSCapabilities{Streaming: true}
+}
+
+func (n *nativeTTS) SynthesizeStream(ctx context.Context, req SynthesizeRequest) (SynthesizeStream, error) {
+	return NewStreamAdapter(&n.textTTS).SynthesizeStream(ctx, req)
+}
+
+func TestAsStreaming(t *testing.T) {
+	native := &nativeTTS{}
+	if AsStreaming(native) != StreamingTTS(native) {
+		t.Error("expected a streaming provider to be used directly")
+	}
+	if _, ok := AsStreaming(&textTTS{}).(*StreamAdapter); !ok {
+		t.Error("expected a non-streaming provider to be adapted")
+	}
+}
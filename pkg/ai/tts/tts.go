@@ -25,6 +25,10 @@ type SynthesizeRequest struct {
 	Language string
 	Speed    float32
 	Pitch    float32
+
+	// WordTimings asks a synthesis stream to report when each word is spoken
+	// (optional, defaults to false)
+	WordTimings bool
 }
 
 // TTSCapabilities describes the capabilities of a TTS provider.
@@ -46,4 +50,46 @@ type TTS interface {
 	
 	// Capabilities returns the provider's capabilities.
 	Capabilities() TTSCapabilities
-}
\ No newline at end of file
+}
+
+// StreamingTTS is implemented by TTS providers that synthesize through
+// sessions, which can also report word timings. Capabilities().Streaming is
+// true when the provider accepts text incrementally, otherwise a session
+// should only be given a single segment of text.
+type StreamingTTS interface {
+	TTS
+
+	// SynthesizeStream opens a synthesis session using the voice settings of req.
+	// req.Text is ignored, text is pushed to the returned stream instead.
+	SynthesizeStream(ctx context.Context, req SynthesizeRequest) (SynthesizeStream, error)
+}
+
+// SynthesizeStream represents an active streaming synthesis session.
+type SynthesizeStream interface {
+	// PushText adds a text fragment. Fragments need not end on word boundaries.
+	PushText(text string) error
+
+	// Flush marks a segment boundary so that buffered text is synthesized
+	// without waiting for more.
+	Flush() error
+
+	// Frames returns a channel of synthesized audio frames. It is closed once
+	// the stream is closed and all audio has been delivered, synthesis fails or
+	// the context is cancelled.
+	Frames() <-chan rtc.AudioFrame
+
+	// Words returns a channel of word timings, in order, when the session was
+	// opened with WordTimings. Offsets are relative to all text pushed to the
+	// stream and times to the start of its audio. Timings may arrive ahead of
+	// the audio they describe. The channel is closed once synthesis stops and
+	// must be drained when timings were requested.
+	Words() <-chan WordTiming
+
+	// Result reports how synthesis ended once Frames is closed, so that a
+	// failure part way through can be told apart from a clean finish. Errors
+	// should be classified with ai.NewRecoverableError or ai.NewFatalError.
+	Result() SynthesisResult
+
+	// Close signals that no more text will be pushed. Buffered text is still synthesized.
+	Close() error
+}
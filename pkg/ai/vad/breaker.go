@@ -0,0 +1,51 @@
+package vad
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// BreakerVAD guards a VAD provider with a circuit breaker. While the breaker
+// is open, detection fails to start with ai.ErrCircuitOpen instead of waiting
+// on a provider that keeps failing. Detection counts as failed if it fails to
+// start or delivers an error event.
+type BreakerVAD struct {
+	vad     VAD
+	breaker *ai.CircuitBreaker
+}
+
+// NewBreakerVAD wraps a VAD provider in a BreakerVAD.
+func NewBreakerVAD(v VAD, cfg ai.BreakerConfig) *BreakerVAD {
+	return &BreakerVAD{vad: v, breaker: ai.NewCircuitBreaker(cfg)}
+}
+
+// Breaker returns the circuit breaker guarding the provider.
+func (b *BreakerVAD) Breaker() *ai.CircuitBreaker {
+	return b.breaker
+}
+
+// Detect starts detection if the breaker allows it.
+func (b *BreakerVAD) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-chan VADEvent, error) {
+	if err := b.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	events, err := b.vad.Detect(ctx, frames)
+	if err != nil {
+		b.breaker.Record(err)
+		return nil, err
+	}
+
+	// Only the first outcome of detection is recorded for the call Allow let through
+	var once sync.Once
+	return reportEvents(ctx, events, func(err error) {
+		once.Do(func() { b.breaker.Record(err) })
+	}), nil
+}
+
+// Capabilities returns the wrapped provider's capabilities.
+func (b *BreakerVAD) Capabilities() VADCapabilities {
+	return b.vad.Capabilities()
+}
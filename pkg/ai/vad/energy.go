@@ -0,0 +1,200 @@
+package vad
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+const (
+	// DefaultEnergyThreshold is a normalized RMS energy above which a frame
+	// is louder than typical background noise
+	DefaultEnergyThreshold = 0.01
+	// DefaultPrefixPadding is the default audio kept before the start of
+	// speech
+	DefaultPrefixPadding = 500 * time.Millisecond
+)
+
+// EnergyVAD detects speech by the RMS energy of each frame. It needs no
+// model and accepts audio at any sample rate, so it serves as a fallback
+// for model-based detectors and as a default where speech only needs to be
+// cut into segments.
+type EnergyVAD struct {
+	threshold     float32
+	prefixPadding time.Duration
+}
+
+// NewEnergyVAD creates an EnergyVAD. Frames with a normalized RMS energy
+// above threshold count as speech, and up to prefixPadding of audio before
+// speech is included in the start and end events.
+func NewEnergyVAD(threshold float32, prefixPadding time.Duration) *EnergyVAD {
+	return &EnergyVAD{threshold: threshold, prefixPadding: prefixPadding}
+}
+
+// Detect implements the VAD interface.
+func (e *EnergyVAD) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-chan VADEvent, error) {
+	events := make(chan VADEvent, 10)
+	go func() {
+		defer close(events)
+		e.detect(ctx, frames, events)
+	}()
+	return events, nil
+}
+
+// Capabilities returns the VAD capabilities. SampleRates is empty since any
+// rate is accepted. Speech starts after three loud frames and ends after ten
+// quiet ones, which for 10ms frames gives the minimum durations reported.
+func (e *EnergyVAD) Capabilities() VADCapabilities {
+	return VADCapabilities{
+		MinSpeechDuration:  30 * time.Millisecond,
+		MinSilenceDuration: 100 * time.Millisecond,
+		Sensitivity:        e.threshold,
+	}
+}
+
+// detect runs detection on frames until they are closed or ctx is done. It
+// buffers the frames of the current segment and up to
+// prefixPadding of audio before it for the start and end events, and sends
+// an inference event per frame. Having no model, it reports the frame's
+// normalized RMS energy as the probability.
+func (e *EnergyVAD) detect(ctx context.Context, frames <-chan rtc.AudioFrame, events chan<- VADEvent) {
+	var isSpeaking bool
+	consecutiveSilence := 0
+	consecutiveSpeech := 0
+
+	var buffered []rtc.AudioFrame // prefix padding followed by the segment's frames
+	speechStart := 0              // index of the segment's first frame in buffered
+	var speechRun, silenceRun time.Duration
+	var segment time.Duration // audio since the segment started
+
+	send := func(event VADEvent) bool {
+		event.Timestamp = time.Now()
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	segmentEvent := func(eventType VADEventType) VADEvent {
+		return VADEvent{
+			Type:            eventType,
+			Speaking:        isSpeaking,
+			SpeechDuration:  segment - silenceRun,
+			SilenceDuration: silenceRun,
+			PrefixPadding:   append([]rtc.AudioFrame(nil), buffered[:speechStart]...),
+			Frames:          append([]rtc.AudioFrame(nil), buffered[speechStart:]...),
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case frame, ok := <-frames:
+			if !ok {
+				// If we were speaking, send speech end event
+				if isSpeaking {
+					isSpeaking = false
+					send(segmentEvent(VADEventSpeechEnd))
+				}
+				return
+			}
+
+			buffered = append(buffered, frame)
+			duration := frame.Duration()
+			if isSpeaking {
+				segment += duration
+			}
+
+			energy := e.calculateRMSEnergy(frame.Data)
+			isVoiceFrame := energy > e.threshold
+
+			var event *VADEvent
+			if isVoiceFrame {
+				consecutiveSpeech++
+				consecutiveSilence = 0
+				speechRun += duration
+				silenceRun = 0
+
+				// Start of speech
+				if !isSpeaking && consecutiveSpeech >= 3 {
+					isSpeaking = true
+					speechStart = len(buffered) - consecutiveSpeech
+					segment = speechRun
+					start := segmentEvent(VADEventSpeechStart)
+					event = &start
+				}
+			} else {
+				consecutiveSilence++
+				consecutiveSpeech = 0
+				silenceRun += duration
+				speechRun = 0
+
+				// End of speech
+				if isSpeaking && consecutiveSilence >= 10 {
+					isSpeaking = false
+					end := segmentEvent(VADEventSpeechEnd)
+					event = &end
+				}
+			}
+
+			inference := VADEvent{
+				Type:            VADEventInferenceDone,
+				Probability:     energy,
+				Speaking:        isSpeaking,
+				SpeechDuration:  speechRun,
+				SilenceDuration: silenceRun,
+			}
+			if isSpeaking {
+				inference.SpeechDuration = segment - silenceRun
+			}
+			if !send(inference) {
+				return
+			}
+			if event != nil && !send(*event) {
+				return
+			}
+
+			if !isSpeaking {
+				buffered, speechStart = e.trim(buffered, consecutiveSpeech), 0
+			}
+		}
+	}
+}
+
+// trim drops buffered frames while no speech is in progress, keeping the
+// current run of speech frames and up to prefixPadding of audio before it.
+func (e *EnergyVAD) trim(buffered []rtc.AudioFrame, speechFrames int) []rtc.AudioFrame {
+	keep := len(buffered) - speechFrames
+	var padding time.Duration
+	for keep > 0 && padding+buffered[keep-1].Duration() <= e.prefixPadding {
+		keep--
+		padding += buffered[keep].Duration()
+	}
+	return buffered[:copy(buffered, buffered[keep:])]
+}
+
+// calculateRMSEnergy computes the RMS energy of an audio frame.
+func (e *EnergyVAD) calculateRMSEnergy(data []byte) float32 {
+	if len(data) < 2 {
+		return 0
+	}
+
+	var sum float64
+	samples := len(data) / 2 // 16-bit samples
+
+	for i := 0; i < samples; i++ {
+		// Convert bytes to 16-bit signed integer using little-endian
+		sample := int16(binary.LittleEndian.Uint16(data[i*2 : i*2+2]))
+		sum += float64(sample) * float64(sample)
+	}
+
+	// Calculate actual RMS (root mean square)
+	meanSquare := sum / float64(samples)
+	rms := math.Sqrt(meanSquare)
+	return float32(rms) / 32768.0 // Normalize to 0-1 range
+}
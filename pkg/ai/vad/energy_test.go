@@ -0,0 +1,90 @@
+package vad
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// pcmFrame returns a 10ms frame of 16 kHz mono audio at a constant level.
+func pcmFrame(level int16) rtc.AudioFrame {
+	data := make([]byte, 320)
+	for i := 0; i < 160; i++ {
+		binary.LittleEndian.PutUint16(data[2*i:], uint16(level))
+	}
+	return rtc.AudioFrame{Data: data, SampleRate: 16000, NumChannels: 1, SamplesPerChannel: 160}
+}
+
+func TestEnergyVAD_SpeechAudio(t *testing.T) {
+	e := NewEnergyVAD(0.1, 20*time.Millisecond)
+
+	// 5 silent frames, 4 loud ones, then 12 silent ones
+	frames := make(chan rtc.AudioFrame, 21)
+	for i := 0; i < 21; i++ {
+		var level int16
+		if i >= 5 && i < 9 {
+			level = 16384
+		}
+		frame := pcmFrame(level)
+		frame.Timestamp = time.Duration(i) * 10 * time.Millisecond // marks the frame
+		frames <- frame
+	}
+	close(frames)
+
+	events, err := e.Detect(context.Background(), frames)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+
+	inferences := 0
+	var segments []VADEvent
+	for event := range events {
+		if event.Type == VADEventInferenceDone {
+			inferences++
+		} else {
+			segments = append(segments, event)
+		}
+	}
+	if inferences != 21 {
+		t.Errorf("expected an inference event per frame, got %d", inferences)
+	}
+	if len(segments) != 2 {
+		t.Fatalf("expected speech start and end events, got %+v", segments)
+	}
+
+	marks := func(frames []rtc.AudioFrame) []int {
+		var m []int
+		for _, f := range frames {
+			m = append(m, int(f.Timestamp/(10*time.Millisecond)))
+		}
+		return m
+	}
+
+	// Speech started on the third loud frame, with two frames of padding
+	start := segments[0]
+	if start.Type != VADEventSpeechStart || !start.Speaking || start.SpeechDuration != 30*time.Millisecond {
+		t.Errorf("unexpected start event: %+v", start)
+	}
+	if got := marks(start.PrefixPadding); len(got) != 2 || got[0] != 3 || got[1] != 4 {
+		t.Errorf("expected frames 3 and 4 as padding, got %v", got)
+	}
+	if got := marks(start.Frames); len(got) != 3 || got[0] != 5 {
+		t.Errorf("expected frames 5 to 7 as speech, got %v", got)
+	}
+
+	// Speech ended after ten silent frames
+	end := segments[1]
+	if end.Type != VADEventSpeechEnd || end.Speaking ||
+		end.SpeechDuration != 40*time.Millisecond || end.SilenceDuration != 100*time.Millisecond {
+		t.Errorf("unexpected end event: %+v", end)
+	}
+	if got := marks(end.Frames); len(got) != 14 || got[0] != 5 || got[13] != 18 {
+		t.Errorf("expected frames 5 to 18 as speech, got %v", got)
+	}
+	if got := marks(end.PrefixPadding); len(got) != 2 {
+		t.Errorf("expected the padding on the end event, got %v", got)
+	}
+}
@@ -60,18 +60,22 @@ func (f *FakeVAD) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-c
 		
 		var isSpeaking bool
 		var speechStartTime time.Time
+		var speech []rtc.AudioFrame // frames of the current segment
+		var speechDuration time.Duration
 		frameCount := 0
 		
 		for {
 			select {
-			case _, ok := <-frames:
+			case frame, ok := <-frames:
 				if !ok {
 					// Send speech end if we were speaking
 					if isSpeaking {
 						select {
 						case output <- vad.VADEvent{
-							Type:      vad.VADEventSpeechEnd,
-							Timestamp: time.Now(),
+							Type:           vad.VADEventSpeechEnd,
+							Timestamp:      time.Now(),
+							SpeechDuration: speechDuration,
+							Frames:         speech,
 						}:
 						case <-ctx.Done():
 							return
@@ -81,6 +85,10 @@ func (f *FakeVAD) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-c
 				}
 				
 				frameCount++
+				if isSpeaking {
+					speech = append(speech, frame)
+					speechDuration += frame.Duration()
+				}
 				
 				// Simple fake logic: randomly determine speech/silence using seeded RNG
 				hasActivity := f.rng.Float32() < f.speechProbability
@@ -90,10 +98,15 @@ func (f *FakeVAD) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-c
 					// Start speaking
 					isSpeaking = true
 					speechStartTime = time.Now()
+					speech = []rtc.AudioFrame{frame}
+					speechDuration = frame.Duration()
 					select {
 					case output <- vad.VADEvent{
-						Type:      vad.VADEventSpeechStart,
-						Timestamp: speechStartTime,
+						Type:           vad.VADEventSpeechStart,
+						Timestamp:      speechStartTime,
+						Speaking:       true,
+						SpeechDuration: speechDuration,
+						Frames:         []rtc.AudioFrame{frame},
 					}:
 					case <-ctx.Done():
 						return
@@ -103,8 +116,10 @@ func (f *FakeVAD) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-c
 					isSpeaking = false
 					select {
 					case output <- vad.VADEvent{
-						Type:      vad.VADEventSpeechEnd,
-						Timestamp: time.Now(),
+						Type:           vad.VADEventSpeechEnd,
+						Timestamp:      time.Now(),
+						SpeechDuration: speechDuration,
+						Frames:         speech,
 					}:
 					case <-ctx.Done():
 						return
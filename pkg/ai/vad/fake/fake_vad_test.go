@@ -181,4 +181,41 @@ func min(a, b int) int {
 		return a
 	}
 	return b
-}
\ No newline at end of file
+}
+func TestFakeVADSpeechFrames(t *testing.T) {
+	provider := NewFakeVADWithSeed(1.0, 42) // 100% speech probability
+
+	frames := make(chan rtc.AudioFrame, 10)
+	for i := 0; i < 10; i++ {
+		frames <- rtc.AudioFrame{
+			Data:              make([]byte, 320),
+			SampleRate:        16000,
+			SamplesPerChannel: 160,
+			NumChannels:       1,
+		}
+	}
+	close(frames)
+
+	events, err := provider.Detect(context.Background(), frames)
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	var end *vad.VADEvent
+	for event := range events {
+		if event.Type == vad.VADEventSpeechEnd {
+			end = &event
+		}
+	}
+	if end == nil {
+		t.Fatal("Expected speech end event")
+	}
+
+	// Speech starts on the fifth frame and runs until the input closes
+	if len(end.Frames) != 6 {
+		t.Errorf("Expected 6 speech frames, got %d", len(end.Frames))
+	}
+	if end.SpeechDuration != 60*time.Millisecond {
+		t.Errorf("Expected 60ms of speech, got %v", end.SpeechDuration)
+	}
+}
@@ -0,0 +1,90 @@
+package vad
+
+import (
+	"context"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// FallbackAdapter starts detection on the first of several VAD providers that
+// is in use, failing over to the next one when a provider fails fatally or
+// repeatedly. Error events during detection count against the provider, so
+// the next detection runs elsewhere. Providers taken out of use are health
+// checked in the background and used again once they recover.
+type FallbackAdapter struct {
+	failover *ai.Failover[VAD]
+}
+
+// NewFallbackAdapter creates a FallbackAdapter for providers, in order of
+// preference. At least one provider is required.
+func NewFallbackAdapter(providers []VAD, cfg ai.FallbackConfig) *FallbackAdapter {
+	return &FallbackAdapter{failover: ai.NewFailover(providers, checkVAD, cfg)}
+}
+
+// checkVAD runs detection on no audio to check that v is working.
+func checkVAD(ctx context.Context, v VAD) error {
+	frames := make(chan rtc.AudioFrame)
+	close(frames)
+	events, err := v.Detect(ctx, frames)
+	if err != nil {
+		return err
+	}
+	for event := range events {
+		if event.Type == VADEventError {
+			return event.Error
+		}
+	}
+	return nil
+}
+
+// Detect starts detection on the first provider that accepts it.
+func (f *FallbackAdapter) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-chan VADEvent, error) {
+	var events <-chan VADEvent
+	i, err := f.failover.Do(ctx, func(v VAD) error {
+		var err error
+		events, err = v.Detect(ctx, frames)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reportEvents(ctx, events, func(err error) { f.failover.Report(i, err) }), nil
+}
+
+// Capabilities returns the capabilities of the primary provider.
+func (f *FallbackAdapter) Capabilities() VADCapabilities {
+	return f.failover.Primary().Capabilities()
+}
+
+// Close stops health checks of providers out of use.
+func (f *FallbackAdapter) Close() error {
+	f.failover.Close()
+	return nil
+}
+
+// reportEvents forwards events and calls report with the error of each error
+// event, or with nil once detection ends without errors.
+func reportEvents(ctx context.Context, events <-chan VADEvent, report func(error)) <-chan VADEvent {
+	reported := make(chan VADEvent, 10)
+	go func() {
+		defer close(reported)
+		failed := false
+		for event := range events {
+			if event.Type == VADEventError {
+				failed = true
+				report(event.Error)
+			}
+			// Events produced after cancellation are discarded
+			select {
+			case reported <- event:
+			case <-ctx.Done():
+			}
+		}
+		if !failed {
+			report(nil)
+		}
+	}()
+	return reported
+}
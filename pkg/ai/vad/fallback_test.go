@@ -0,0 +1,51 @@
+package vad
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// eventVAD delivers a single event per detection.
+type eventVAD struct {
+	event    VADEvent
+	detected int
+}
+
+func (e *eventVAD) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-chan VADEvent, error) {
+	e.detected++
+	events := make(chan VADEvent, 1)
+	events <- e.event
+	close(events)
+	return events, nil
+}
+
+func (e *eventVAD) Capabilities() VADCapabilities {
+	return VADCapabilities{SampleRates: []int{16000}}
+}
+
+func TestFallbackAdapter_ErrorEventsFailOver(t *testing.T) {
+	primary := &eventVAD{event: VADEvent{Type: VADEventError, Error: ai.NewFatalError(errors.New("bad model"), "invalid model")}}
+	secondary := &eventVAD{event: VADEvent{Type: VADEventSpeechStart}}
+	adapter := NewFallbackAdapter([]VAD{primary, secondary}, ai.FallbackConfig{HealthCheckInterval: time.Hour})
+	defer adapter.Close()
+
+	for i := 0; i < 2; i++ {
+		events, err := adapter.Detect(context.Background(), make(chan rtc.AudioFrame))
+		if err != nil {
+			t.Fatalf("Detect failed: %v", err)
+		}
+		for range events {
+		}
+	}
+	if primary.detected != 1 || secondary.detected != 1 {
+		t.Errorf("expected the second detection on the secondary, got %d/%d", primary.detected, secondary.detected)
+	}
+	if adapter.Capabilities().SampleRates[0] != 16000 {
+		t.Errorf("expected the primary's capabilities")
+	}
+}
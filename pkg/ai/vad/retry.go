@@ -0,0 +1,40 @@
+package vad
+
+import (
+	"context"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// RetryVAD retries starting detection on a VAD provider when it fails with a
+// recoverable error. Errors during detection are delivered as error events and
+// are not retried, since the provider has already consumed the frames.
+type RetryVAD struct {
+	vad VAD
+	cfg ai.RetryConfig
+}
+
+// NewRetryVAD wraps a VAD provider in a RetryVAD.
+func NewRetryVAD(v VAD, cfg ai.RetryConfig) *RetryVAD {
+	return &RetryVAD{vad: v, cfg: cfg}
+}
+
+// Detect starts detection, retrying recoverable failures.
+func (r *RetryVAD) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-chan VADEvent, error) {
+	var events <-chan VADEvent
+	err := ai.Retry(ctx, r.cfg, func(ctx context.Context) error {
+		var err error
+		events, err = r.vad.Detect(ctx, frames)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Capabilities returns the wrapped provider's capabilities.
+func (r *RetryVAD) Capabilities() VADCapabilities {
+	return r.vad.Capabilities()
+}
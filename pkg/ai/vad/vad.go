@@ -26,6 +26,10 @@ const (
 	VADEventSpeechStart VADEventType = iota
 	VADEventSpeechEnd
 	VADEventError
+	// VADEventInferenceDone reports the speech probability of one window of
+	// audio. Detectors without a model may report another measure of speech
+	// in [0, 1], such as normalized energy.
+	VADEventInferenceDone
 )
 
 // VADEvent represents a voice activity detection event.
@@ -33,6 +37,34 @@ type VADEvent struct {
 	Type      VADEventType
 	Timestamp time.Time
 	Error     error
+
+	// Probability is the speech probability of the window an InferenceDone
+	// event reports, and InferenceDuration how long the model took for it
+	Probability       float32
+	InferenceDuration time.Duration
+
+	// Speaking reports whether speech is in progress after the event
+	Speaking bool
+
+	// SpeechDuration is the speech detected so far in the current segment,
+	// and SilenceDuration the silence since it last ended (the trailing
+	// silence on SpeechEnd)
+	SpeechDuration  time.Duration
+	SilenceDuration time.Duration
+
+	// Frames is the speech audio of the segment, from where speech was
+	// detected to the event, on SpeechStart and SpeechEnd events.
+	// PrefixPadding is the audio just before it, so that STT hears the
+	// onset of the first word.
+	Frames        []rtc.AudioFrame
+	PrefixPadding []rtc.AudioFrame
+}
+
+// Audio returns the prefix padding followed by the speech frames.
+func (e VADEvent) Audio() []rtc.AudioFrame {
+	audio := make([]rtc.AudioFrame, 0, len(e.PrefixPadding)+len(e.Frames))
+	audio = append(audio, e.PrefixPadding...)
+	return append(audio, e.Frames...)
 }
 
 // VADCapabilities describes the capabilities of a VAD provider.
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+
+	"github.com/chriscow/livekit-agents-go/pkg/metrics"
 )
 
 // New creates a new Job with the given configuration.
@@ -39,8 +41,16 @@ func New(parentCtx context.Context, cfg Config) (*Job, error) {
 		ID:       jobID,
 		RoomName: cfg.RoomName,
 		Context:  jobContext,
+		Usage:    metrics.NewCollector(),
 	}
 
+	// Report what the job used once it ends, for cost reporting
+	jobContext.OnShutdown(func(reason string) {
+		slog.Info("Job usage",
+			slog.String("job_id", jobID),
+			slog.String("usage", job.Usage.Summary().String()))
+	})
+
 	slog.Info("Created new job",
 		slog.String("job_id", jobID),
 		slog.String("room_name", cfg.RoomName),
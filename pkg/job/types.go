@@ -4,6 +4,8 @@ import (
 	"context"
 	"sync"
 	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/metrics"
 )
 
 // Job represents a single agent job execution context.
@@ -17,6 +19,9 @@ type Job struct {
 
 	// Context provides lifecycle management and shutdown coordination
 	Context *JobContext
+
+	// Usage aggregates the AI provider usage of the job, summarized when it shuts down
+	Usage *metrics.Collector
 }
 
 // JobContext manages the lifecycle and cleanup of a job.
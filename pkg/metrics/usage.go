@@ -0,0 +1,139 @@
+// Package metrics records how much agents use their AI providers, for cost
+// reporting and usage budgets.
+package metrics
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Kind identifies the kind of provider a usage was recorded for.
+type Kind string
+
+const (
+	KindLLM Kind = "llm"
+	KindSTT Kind = "stt"
+	KindTTS Kind = "tts"
+)
+
+// Usage is the usage of a single provider call. Only the fields that apply to
+// its Kind are set.
+type Usage struct {
+	Kind Kind
+
+	// LLM tokens. TotalTokens is set even if the provider does not report
+	// the prompt and completion split.
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+
+	// AudioDuration is the audio sent to STT or produced by TTS
+	AudioDuration time.Duration
+
+	// Characters is the text sent to TTS
+	Characters int
+
+	Timestamp time.Time
+}
+
+// Summary aggregates usage over a session.
+type Summary struct {
+	LLMRequests      int
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+
+	STTStreams       int
+	STTAudioDuration time.Duration
+
+	TTSRequests      int
+	TTSCharacters    int
+	TTSAudioDuration time.Duration
+}
+
+// add adds a usage to the summary.
+func (s *Summary) add(u Usage) {
+	switch u.Kind {
+	case KindLLM:
+		s.LLMRequests++
+		s.PromptTokens += u.PromptTokens
+		s.CompletionTokens += u.CompletionTokens
+		s.TotalTokens += u.TotalTokens
+	case KindSTT:
+		s.STTStreams++
+		s.STTAudioDuration += u.AudioDuration
+	case KindTTS:
+		s.TTSRequests++
+		s.TTSCharacters += u.Characters
+		s.TTSAudioDuration += u.AudioDuration
+	}
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("llm: %d requests, %d tokens (%d prompt, %d completion); stt: %d streams, %v audio; tts: %d requests, %d characters, %v audio",
+		s.LLMRequests, s.TotalTokens, s.PromptTokens, s.CompletionTokens,
+		s.STTStreams, s.STTAudioDuration.Round(time.Millisecond),
+		s.TTSRequests, s.TTSCharacters, s.TTSAudioDuration.Round(time.Millisecond))
+}
+
+// Collector aggregates the usage recorded during a job or session. It is safe
+// for concurrent use.
+type Collector struct {
+	mu       sync.Mutex
+	summary  Summary
+	handlers map[int]func(Usage)
+	nextID   int
+}
+
+// NewCollector creates an empty Collector.
+func NewCollector() *Collector {
+	return &Collector{handlers: make(map[int]func(Usage))}
+}
+
+// Record adds the usage of a provider call, stamping it with the current time
+// if Timestamp is not set, and passes it to the handlers registered with OnUsage.
+func (c *Collector) Record(u Usage) {
+	if u.Timestamp.IsZero() {
+		u.Timestamp = time.Now()
+	}
+	if u.Kind == KindLLM && u.TotalTokens == 0 {
+		u.TotalTokens = u.PromptTokens + u.CompletionTokens
+	}
+
+	c.mu.Lock()
+	c.summary.add(u)
+	handlers := make([]func(Usage), 0, len(c.handlers))
+	for _, handler := range c.handlers {
+		handlers = append(handlers, handler)
+	}
+	c.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(u)
+	}
+}
+
+// Summary returns the usage recorded so far.
+func (c *Collector) Summary() Summary {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.summary
+}
+
+// OnUsage registers a handler that is called with every usage recorded, for
+// example to enforce a budget by checking Summary. Handlers run synchronously
+// on the recording goroutine and must not block. The returned function
+// removes the handler.
+func (c *Collector) OnUsage(handler func(Usage)) (remove func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id := c.nextID
+	c.nextID++
+	c.handlers[id] = handler
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.handlers, id)
+	}
+}
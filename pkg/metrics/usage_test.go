@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCollector_Summary(t *testing.T) {
+	c := NewCollector()
+	c.Record(Usage{Kind: KindLLM, PromptTokens: 100, CompletionTokens: 20})
+	c.Record(Usage{Kind: KindLLM, TotalTokens: 50})
+	c.Record(Usage{Kind: KindSTT, AudioDuration: 3 * time.Second})
+	c.Record(Usage{Kind: KindTTS, Characters: 42, AudioDuration: 2 * time.Second})
+	c.Record(Usage{Kind: KindTTS, Characters: 8, AudioDuration: 500 * time.Millisecond})
+
+	want := Summary{
+		LLMRequests:      2,
+		PromptTokens:     100,
+		CompletionTokens: 20,
+		TotalTokens:      170,
+		STTStreams:       1,
+		STTAudioDuration: 3 * time.Second,
+		TTSRequests:      2,
+		TTSCharacters:    50,
+		TTSAudioDuration: 2500 * time.Millisecond,
+	}
+	if got := c.Summary(); got != want {
+		t.Errorf("expected summary %+v, got %+v", want, got)
+	}
+}
+
+func TestCollector_OnUsage(t *testing.T) {
+	c := NewCollector()
+
+	var mu sync.Mutex
+	var recorded []Usage
+	remove := c.OnUsage(func(u Usage) {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded = append(recorded, u)
+	})
+
+	c.Record(Usage{Kind: KindTTS, Characters: 5})
+	remove()
+	c.Record(Usage{Kind: KindTTS, Characters: 7})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(recorded) != 1 {
+		t.Fatalf("expected 1 usage before the handler was removed, got %d", len(recorded))
+	}
+	if recorded[0].Characters != 5 || recorded[0].Timestamp.IsZero() {
+		t.Errorf("expected a timestamped usage of 5 characters, got %+v", recorded[0])
+	}
+}
@@ -0,0 +1,39 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// classifyError wraps an OpenAI error as recoverable or fatal. Rate limits,
+// timeouts, server errors and network failures may succeed if retried, other
+// API errors such as an invalid key or request will not. Context cancellation
+// is returned unclassified so callers can still detect it.
+func classifyError(err error, message string) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%s: %w", message, err)
+	}
+
+	status := 0
+	var apiErr *openai.APIError
+	var reqErr *openai.RequestError
+	switch {
+	case errors.As(err, &apiErr):
+		status = apiErr.HTTPStatusCode
+	case errors.As(err, &reqErr):
+		status = reqErr.HTTPStatusCode
+	}
+
+	message = fmt.Sprintf("%s: %v", message, err)
+	switch {
+	case status == 0, status == http.StatusRequestTimeout, status == http.StatusTooManyRequests, status >= 500:
+		return ai.NewRecoverableError(err, message)
+	default:
+		return ai.NewFatalError(err, message)
+	}
+}
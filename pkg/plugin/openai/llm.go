@@ -2,7 +2,9 @@ package openai
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"time"
@@ -48,13 +50,153 @@ func (o *OpenAILLM) Chat(ctx context.Context, req llm.ChatRequest) (llm.ChatResp
 	log.Printf("🤖 Starting OpenAI chat completion with %d messages (model: %s)", len(req.Messages), o.model)
 	start := time.Now()
 
+	completionReq := o.completionRequest(req)
+
+	resp, err := o.client.CreateChatCompletion(ctx, completionReq)
+	if err != nil {
+		log.Printf("❌ OpenAI chat completion failed: %v", err)
+		return llm.ChatResponse{}, classifyError(err, "chat completion request failed")
+	}
+
+	if len(resp.Choices) == 0 {
+		log.Printf("❌ OpenAI returned no completion choices")
+		return llm.ChatResponse{}, fmt.Errorf("no chat completion choices returned")
+	}
+
+	choice := resp.Choices[0]
+	duration := time.Since(start)
+
+	toolCalls := fromOpenAIToolCalls(choice.Message.ToolCalls)
+	result := llm.ChatResponse{
+		Message: llm.Message{
+			Role:      llm.MessageRole(choice.Message.Role),
+			Content:   choice.Message.Content,
+			ToolCalls: toolCalls,
+		},
+		FunctionCall: firstFunctionCall(toolCalls),
+		ToolCalls:    toolCalls,
+		TokensUsed:   resp.Usage.TotalTokens,
+		FinishReason: string(choice.FinishReason),
+
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+	}
+
+	log.Printf("✅ OpenAI chat completion successful: '%s' (tokens: %d, duration: %v)",
+		choice.Message.Content, resp.Usage.TotalTokens, duration)
+
+	return result, nil
+}
+
+// ChatStream performs a streaming chat completion, emitting content deltas as they arrive.
+// Tool call fragments are accumulated and delivered on the final chunk.
+func (o *OpenAILLM) ChatStream(ctx context.Context, req llm.ChatRequest) (<-chan llm.ChatChunk, error) {
+	log.Printf("🤖 Starting OpenAI streaming chat completion with %d messages (model: %s)", len(req.Messages), o.model)
+	start := time.Now()
+
+	completionReq := o.completionRequest(req)
+	completionReq.Stream = true
+	completionReq.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+
+	stream, err := o.client.CreateChatCompletionStream(ctx, completionReq)
+	if err != nil {
+		log.Printf("❌ OpenAI chat completion stream failed: %v", err)
+		return nil, classifyError(err, "chat completion stream request failed")
+	}
+
+	chunks := make(chan llm.ChatChunk, 10)
+	go func() {
+		defer close(chunks)
+		defer stream.Close()
+
+		var (
+			finishReason string
+			usage        openai.Usage
+			toolCalls    toolCallAccumulator
+		)
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				log.Printf("❌ OpenAI chat completion stream error: %v", err)
+				select {
+				case chunks <- llm.ChatChunk{Error: classifyError(err, "chat completion stream failed")}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			if resp.Usage != nil {
+				usage = *resp.Usage
+			}
+			if len(resp.Choices) == 0 {
+				continue
+			}
+
+			choice := resp.Choices[0]
+			if choice.FinishReason != "" {
+				finishReason = string(choice.FinishReason)
+			}
+
+			for _, toolCall := range choice.Delta.ToolCalls {
+				toolCalls.add(toolCall)
+			}
+
+			if choice.Delta.Content == "" {
+				continue
+			}
+			select {
+			case chunks <- llm.ChatChunk{Delta: choice.Delta.Content}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		calls := toolCalls.toolCalls()
+		final := llm.ChatChunk{
+			FunctionCall: firstFunctionCall(calls),
+			ToolCalls:    calls,
+			TokensUsed:   usage.TotalTokens,
+			FinishReason: finishReason,
+
+			PromptTokens:     usage.PromptTokens,
+			CompletionTokens: usage.CompletionTokens,
+		}
+
+		log.Printf("✅ OpenAI streaming chat completion finished (tokens: %d, duration: %v)", usage.TotalTokens, time.Since(start))
+
+		select {
+		case chunks <- final:
+		case <-ctx.Done():
+		}
+	}()
+
+	return chunks, nil
+}
+
+// completionRequest converts an llm.ChatRequest to the OpenAI request format.
+func (o *OpenAILLM) completionRequest(req llm.ChatRequest) openai.ChatCompletionRequest {
 	// Convert messages to OpenAI format
 	openaiMessages := make([]openai.ChatCompletionMessage, len(req.Messages))
 	for i, msg := range req.Messages {
 		openaiMessages[i] = openai.ChatCompletionMessage{
-			Role:    string(msg.Role),
-			Content: msg.Content,
-			Name:    msg.Name,
+			Role:       string(msg.Role),
+			Content:    msg.Content,
+			Name:       msg.Name,
+			ToolCallID: msg.ToolCallID,
+		}
+		for _, toolCall := range msg.ToolCalls {
+			openaiMessages[i].ToolCalls = append(openaiMessages[i].ToolCalls, openai.ToolCall{
+				ID:   toolCall.ID,
+				Type: openai.ToolTypeFunction,
+				Function: openai.FunctionCall{
+					Name:      toolCall.Name,
+					Arguments: toolCall.Arguments,
+				},
+			})
 		}
 	}
 
@@ -74,7 +216,7 @@ func (o *OpenAILLM) Chat(ctx context.Context, req llm.ChatRequest) (llm.ChatResp
 		}
 	}
 
-	completionReq := openai.ChatCompletionRequest{
+	return openai.ChatCompletionRequest{
 		Model:       o.model,
 		Messages:    openaiMessages,
 		MaxTokens:   req.MaxTokens,
@@ -82,50 +224,82 @@ func (o *OpenAILLM) Chat(ctx context.Context, req llm.ChatRequest) (llm.ChatResp
 		TopP:        req.TopP,
 		Tools:       tools,
 	}
+}
 
-	resp, err := o.client.CreateChatCompletion(ctx, completionReq)
-	if err != nil {
-		log.Printf("❌ OpenAI chat completion failed: %v", err)
-		return llm.ChatResponse{}, fmt.Errorf("chat completion request failed: %w", err)
+// fromOpenAIToolCalls converts OpenAI tool calls, preserving their order.
+func fromOpenAIToolCalls(toolCalls []openai.ToolCall) []llm.ToolCall {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+	result := make([]llm.ToolCall, len(toolCalls))
+	for i, toolCall := range toolCalls {
+		result[i] = llm.ToolCall{
+			ID:        toolCall.ID,
+			Name:      toolCall.Function.Name,
+			Arguments: toolCall.Function.Arguments,
+		}
 	}
+	return result
+}
 
-	if len(resp.Choices) == 0 {
-		log.Printf("❌ OpenAI returned no completion choices")
-		return llm.ChatResponse{}, fmt.Errorf("no chat completion choices returned")
+// firstFunctionCall returns the first tool call as a FunctionCall for callers
+// that only handle a single call.
+func firstFunctionCall(toolCalls []llm.ToolCall) *llm.FunctionCall {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+	return &llm.FunctionCall{
+		Name:      toolCalls[0].Name,
+		Arguments: toolCalls[0].Arguments,
 	}
+}
 
-	choice := resp.Choices[0]
-	duration := time.Since(start)
+// toolCallAccumulator assembles streamed tool call fragments. Each fragment
+// carries the index of the call it belongs to; the ID and name arrive with the
+// first fragment and the arguments are spread across the rest.
+type toolCallAccumulator struct {
+	calls []llm.ToolCall
+}
 
-	result := llm.ChatResponse{
-		Message: llm.Message{
-			Role:    llm.MessageRole(choice.Message.Role),
-			Content: choice.Message.Content,
-		},
-		TokensUsed:   resp.Usage.TotalTokens,
-		FinishReason: string(choice.FinishReason),
+// add merges a streamed fragment into the call at its index.
+func (t *toolCallAccumulator) add(fragment openai.ToolCall) {
+	index := len(t.calls) - 1
+	if fragment.Index != nil {
+		index = *fragment.Index
 	}
-
-	// Handle function calls - convert first tool call to function call
-	if len(choice.Message.ToolCalls) > 0 {
-		toolCall := choice.Message.ToolCalls[0]
-		result.FunctionCall = &llm.FunctionCall{
-			Name:      toolCall.Function.Name,
-			Arguments: toolCall.Function.Arguments,
-		}
+	if index < 0 {
+		index = 0
+	}
+	for len(t.calls) <= index {
+		t.calls = append(t.calls, llm.ToolCall{})
 	}
 
-	log.Printf("✅ OpenAI chat completion successful: '%s' (tokens: %d, duration: %v)",
-		choice.Message.Content, resp.Usage.TotalTokens, duration)
+	call := &t.calls[index]
+	if fragment.ID != "" {
+		call.ID = fragment.ID
+	}
+	if fragment.Function.Name != "" {
+		call.Name = fragment.Function.Name
+	}
+	call.Arguments += fragment.Function.Arguments
+}
 
-	return result, nil
+// toolCalls returns the assembled calls in index order.
+func (t *toolCallAccumulator) toolCalls() []llm.ToolCall {
+	var calls []llm.ToolCall
+	for _, call := range t.calls {
+		if call.Name != "" {
+			calls = append(calls, call)
+		}
+	}
+	return calls
 }
 
 // Capabilities returns the OpenAI provider's capabilities
 func (o *OpenAILLM) Capabilities() llm.LLMCapabilities {
 	return llm.LLMCapabilities{
 		SupportsFunctions:   true,
-		SupportsStreaming:   false, // Not implementing streaming yet
+		SupportsStreaming:   true,
 		MaxTokens:          128000, // GPT-4 context length
 		SupportedModels:    []string{"gpt-3.5-turbo", "gpt-4", "gpt-4-turbo", "gpt-4o"},
 		SupportsSystemRole: true,
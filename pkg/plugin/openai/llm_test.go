@@ -0,0 +1,55 @@
+package openai
+
+import (
+	"testing"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/llm"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+func TestToolCallAccumulator(t *testing.T) {
+	first, second := 0, 1
+	fragments := []openai.ToolCall{
+		{Index: &first, ID: "call_a", Function: openai.FunctionCall{Name: "get_weather", Arguments: `{"city":`}},
+		{Index: &second, ID: "call_b", Function: openai.FunctionCall{Name: "get_time", Arguments: `{"tz":`}},
+		{Index: &first, Function: openai.FunctionCall{Arguments: `"Paris"}`}},
+		{Index: &second, Function: openai.FunctionCall{Arguments: `"CET"}`}},
+	}
+
+	var acc toolCallAccumulator
+	for _, fragment := range fragments {
+		acc.add(fragment)
+	}
+
+	calls := acc.toolCalls()
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 tool calls, got %d", len(calls))
+	}
+	want := []llm.ToolCall{
+		{ID: "call_a", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+		{ID: "call_b", Name: "get_time", Arguments: `{"tz":"CET"}`},
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("Tool call %d: expected %+v, got %+v", i, want[i], calls[i])
+		}
+	}
+}
+
+func TestCompletionRequest_ToolMessages(t *testing.T) {
+	o := &OpenAILLM{model: "gpt-4o"}
+	req := o.completionRequest(llm.ChatRequest{
+		Messages: []llm.Message{
+			{Role: llm.RoleAssistant, ToolCalls: []llm.ToolCall{{ID: "call_a", Name: "get_weather", Arguments: "{}"}}},
+			{Role: llm.RoleTool, Name: "get_weather", Content: "sunny", ToolCallID: "call_a"},
+		},
+	})
+
+	assistant := req.Messages[0]
+	if len(assistant.ToolCalls) != 1 || assistant.ToolCalls[0].ID != "call_a" || assistant.ToolCalls[0].Type != openai.ToolTypeFunction {
+		t.Errorf("Expected assistant tool calls to be converted, got %+v", assistant.ToolCalls)
+	}
+	if req.Messages[1].ToolCallID != "call_a" {
+		t.Errorf("Expected tool result to reference the call ID, got %q", req.Messages[1].ToolCallID)
+	}
+}
@@ -69,6 +69,7 @@ func init() {
 			"api_key": "OpenAI API key (or set OPENAI_API_KEY env var)",
 			"model":   "tts-1",
 			"voice":   "alloy",
+			"base_url": "API base URL (optional, for OpenAI-compatible servers)",
 		},
 	})
 }
\ No newline at end of file
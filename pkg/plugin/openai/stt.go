@@ -8,10 +8,12 @@ import (
 	"encoding/binary"
 	"fmt"
 	"log/slog"
-	"sync"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/chriscow/livekit-agents-go/pkg/ai/stt"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/vad"
 	"github.com/chriscow/livekit-agents-go/pkg/rtc"
 	openai "github.com/sashabaranov/go-openai"
 )
@@ -21,6 +23,7 @@ type WhisperSTT struct {
 	client   *openai.Client
 	model    string
 	language string
+	vad      vad.VAD
 }
 
 // Config holds configuration for OpenAI STT.
@@ -28,6 +31,11 @@ type Config struct {
 	APIKey   string `json:"api_key"`
 	Model    string `json:"model"`    // Default: whisper-1
 	Language string `json:"language"` // Default: auto-detect (empty)
+
+	// VAD cuts streams into utterances, each transcribed once it ends
+	// (optional, defaults to a vad.EnergyVAD with its default threshold and
+	// prefix padding)
+	VAD vad.VAD `json:"-"`
 }
 
 // NewWhisperSTT creates a new OpenAI Whisper STT provider.
@@ -41,31 +49,31 @@ func NewWhisperSTT(cfg Config) (*WhisperSTT, error) {
 		model = openai.Whisper1
 	}
 
+	detector := cfg.VAD
+	if detector == nil {
+		detector = vad.NewEnergyVAD(vad.DefaultEnergyThreshold, vad.DefaultPrefixPadding)
+	}
+
 	return &WhisperSTT{
 		client:   openai.NewClient(cfg.APIKey),
 		model:    model,
 		language: cfg.Language,
+		vad:      detector,
 	}, nil
 }
 
-// NewStream creates a new STT streaming session.
+// NewStream creates a new STT streaming session. Whisper has no streaming
+// API, so the stream is a StreamAdapter that transcribes each utterance cut
+// by the VAD.
 func (w *WhisperSTT) NewStream(ctx context.Context, cfg stt.StreamConfig) (stt.STTStream, error) {
-	stream := &whisperStream{
-		stt:         w,
-		ctx:         ctx,
-		config:      cfg,
-		eventChan:   make(chan stt.SpeechEvent, 10),
-		audioBuffer: make([]rtc.AudioFrame, 0),
-	}
-
-	go stream.processLoop()
-	return stream, nil
+	return stt.NewStreamAdapter(w, w.vad).NewStream(ctx, cfg)
 }
 
-// Capabilities returns the STT capabilities.
+// Capabilities returns the STT capabilities. Streams only accept the sample
+// rates the VAD does, if it restricts them.
 func (w *WhisperSTT) Capabilities() stt.STTCapabilities {
-	return stt.STTCapabilities{
-		Streaming:      true, // Pseudo-streaming via batching
+	caps := stt.STTCapabilities{
+		Streaming:      true, // Pseudo-streaming via stt.StreamAdapter
 		InterimResults: false, // Whisper doesn't support interim results
 		SupportedLanguages: []string{
 			"en", "zh", "de", "es", "ru", "ko", "fr", "ja", "pt", "tr", "pl", "ca", "nl",
@@ -79,138 +87,41 @@ func (w *WhisperSTT) Capabilities() stt.STTCapabilities {
 		},
 		SampleRates: []int{16000, 22050, 44100, 48000},
 	}
-}
-
-// whisperStream implements STT streaming using batched processing.
-type whisperStream struct {
-	stt         *WhisperSTT
-	ctx         context.Context
-	config      stt.StreamConfig
-	eventChan   chan stt.SpeechEvent
-	audioBuffer []rtc.AudioFrame
-	closed      bool
-	mu          sync.Mutex
-}
-
-// Push sends an audio frame for processing.
-func (s *whisperStream) Push(frame rtc.AudioFrame) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if s.closed {
-		return fmt.Errorf("stream is closed")
-	}
-
-	s.audioBuffer = append(s.audioBuffer, frame)
-	return nil
-}
-
-// Events returns the channel for receiving speech events.
-func (s *whisperStream) Events() <-chan stt.SpeechEvent {
-	return s.eventChan
-}
-
-// CloseSend signals that no more audio will be sent.
-func (s *whisperStream) CloseSend() error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if s.closed {
-		return fmt.Errorf("stream already closed")
+	if rates := w.vad.Capabilities().SampleRates; len(rates) > 0 {
+		caps.SampleRates = rates
 	}
-
-	s.closed = true
-	return nil
+	return caps
 }
 
-// processLoop runs the background processing loop.
-func (s *whisperStream) processLoop() {
-	defer close(s.eventChan)
-
-	ticker := time.NewTicker(3 * time.Second) // Process every 3 seconds
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-s.ctx.Done():
-			return
-		case <-ticker.C:
-			s.processBufferedAudio(false)
-		}
-
-		// Check if stream is closed and process final audio
-		s.mu.Lock()
-		if s.closed {
-			s.mu.Unlock()
-			s.processBufferedAudio(true)
-			return
-		}
-		s.mu.Unlock()
-	}
-}
-
-// processBufferedAudio processes currently buffered audio frames.
-func (s *whisperStream) processBufferedAudio(isFinal bool) {
-	s.mu.Lock()
-	if len(s.audioBuffer) == 0 {
-		s.mu.Unlock()
-		return
-	}
-
-	frames := make([]rtc.AudioFrame, len(s.audioBuffer))
-	copy(frames, s.audioBuffer)
-	
-	if !isFinal {
-		// Keep last few frames for continuity
-		keepFrames := 2
-		if len(s.audioBuffer) > keepFrames {
-			s.audioBuffer = s.audioBuffer[len(s.audioBuffer)-keepFrames:]
-		}
-	} else {
-		s.audioBuffer = nil
-	}
-	s.mu.Unlock()
+// Recognize transcribes a complete buffer of audio, with word timings.
+func (w *WhisperSTT) Recognize(ctx context.Context, frames []rtc.AudioFrame, opts stt.RecognizeOptions) (*stt.Recognition, error) {
+	language := w.languageFor(opts.Language)
 
 	// Combine frames into single audio data
-	combined, err := s.combineFrames(frames)
+	combined, err := combineFrames(frames)
 	if err != nil {
-		slog.Error("Failed to combine audio frames", slog.String("error", err.Error()))
-		s.sendErrorEvent(err)
-		return
+		return nil, err
 	}
 
 	// Check minimum duration requirement (OpenAI requires ≥ 0.1 seconds)
 	minDuration := 100 * time.Millisecond
 	if combined.duration < minDuration {
-		if isFinal {
-			// Send empty result for final processing
-			s.sendFinalEvent("", "")
-		}
-		return
+		return &stt.Recognition{Language: language}, nil
 	}
 
 	// Convert to WAV and transcribe
-	wavData, err := s.convertToWAV(combined)
+	wavData, err := convertToWAV(combined)
 	if err != nil {
 		slog.Error("Failed to convert audio to WAV", slog.String("error", err.Error()))
-		s.sendErrorEvent(err)
-		return
+		return nil, err
 	}
 
-	// Call OpenAI Whisper API  
-	text, language, err := s.transcribe(wavData)
+	result, err := w.transcribe(ctx, wavData, language)
 	if err != nil {
 		slog.Error("Whisper transcription failed", slog.String("error", err.Error()))
-		s.sendErrorEvent(err)
-		return
-	}
-
-	// Send result
-	if isFinal {
-		s.sendFinalEvent(text, language)
-	} else if text != "" {
-		s.sendFinalEvent(text, language) // Whisper doesn't support interim results
+		return nil, err
 	}
+	return result, nil
 }
 
 // combinedAudio represents combined audio data.
@@ -222,7 +133,7 @@ type combinedAudio struct {
 }
 
 // combineFrames combines multiple audio frames into a single buffer.
-func (s *whisperStream) combineFrames(frames []rtc.AudioFrame) (*combinedAudio, error) {
+func combineFrames(frames []rtc.AudioFrame) (*combinedAudio, error) {
 	if len(frames) == 0 {
 		return nil, fmt.Errorf("no frames to combine")
 	}
@@ -235,7 +146,10 @@ func (s *whisperStream) combineFrames(frames []rtc.AudioFrame) (*combinedAudio,
 
 	for _, frame := range frames {
 		totalSize += len(frame.Data)
-		totalDuration += frame.Timestamp
+	}
+	if sampleRate > 0 {
+		samples := totalSize / (2 * max(channels, 1))
+		totalDuration = time.Duration(samples) * time.Second / time.Duration(sampleRate)
 	}
 
 	// Combine data
@@ -253,7 +167,7 @@ func (s *whisperStream) combineFrames(frames []rtc.AudioFrame) (*combinedAudio,
 }
 
 // convertToWAV converts audio data to WAV format for OpenAI API.
-func (s *whisperStream) convertToWAV(audio *combinedAudio) ([]byte, error) {
+func convertToWAV(audio *combinedAudio) ([]byte, error) {
 	var buf bytes.Buffer
 
 	// RIFF header
@@ -295,54 +209,75 @@ func (s *whisperStream) convertToWAV(audio *combinedAudio) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// transcribe calls the OpenAI Whisper API.
-func (s *whisperStream) transcribe(wavData []byte) (string, string, error) {
+// transcribe calls the OpenAI Whisper API. The language is the requested
+// one, or the code of the one Whisper detected.
+func (w *WhisperSTT) transcribe(ctx context.Context, wavData []byte, language string) (*stt.Recognition, error) {
 	reader := bytes.NewReader(wavData)
 
 	req := openai.AudioRequest{
-		Model:    s.stt.model,
-		Language: s.stt.language,
-		Format:   openai.AudioResponseFormatJSON,
+		Model:    w.model,
+		Language: language,
+		Format:   openai.AudioResponseFormatVerboseJSON,
 		Reader:   reader,
 		FilePath: "audio.wav",
+		TimestampGranularities: []openai.TranscriptionTimestampGranularity{
+			openai.TranscriptionTimestampGranularityWord,
+			openai.TranscriptionTimestampGranularitySegment,
+		},
 	}
 
-	response, err := s.stt.client.CreateTranscription(s.ctx, req)
+	response, err := w.client.CreateTranscription(ctx, req)
 	if err != nil {
-		return "", "", fmt.Errorf("transcription failed: %w", err)
+		return nil, classifyError(err, "transcription failed")
 	}
 
 	slog.Debug("Whisper transcription result", slog.String("text", response.Text))
 
-	return response.Text, response.Language, nil
+	return newRecognition(response, language), nil
 }
 
-// sendFinalEvent sends a final speech event.
-func (s *whisperStream) sendFinalEvent(text, language string) {
-	event := stt.SpeechEvent{
-		Type:      stt.SpeechEventFinal,
-		Text:      text,
-		IsFinal:   true,
-		Language:  language,
-		Timestamp: time.Now().UnixMilli(),
+// newRecognition converts a verbose Whisper response. Whisper reports no
+// word confidence; the transcript's confidence is the mean token probability
+// of its segments.
+func newRecognition(response openai.AudioResponse, language string) *stt.Recognition {
+	result := &stt.Recognition{
+		Text:     strings.TrimSpace(response.Text),
+		Language: language,
+	}
+	if result.Language == "" {
+		result.Language = languageCode(response.Language)
 	}
 
-	select {
-	case s.eventChan <- event:
-	case <-s.ctx.Done():
+	for _, word := range response.Words {
+		result.Words = append(result.Words, stt.Word{
+			Text:  word.Word,
+			Start: seconds(word.Start),
+			End:   seconds(word.End),
+		})
 	}
-}
 
-// sendErrorEvent sends an error event.
-func (s *whisperStream) sendErrorEvent(err error) {
-	event := stt.SpeechEvent{
-		Type:      stt.SpeechEventError,
-		Error:     err,
-		Timestamp: time.Now().UnixMilli(),
+	if len(response.Segments) > 0 {
+		var logprob float64
+		for _, segment := range response.Segments {
+			logprob += segment.AvgLogprob
+		}
+		result.Confidence = float32(math.Exp(logprob / float64(len(response.Segments))))
 	}
+	return result
+}
 
-	select {
-	case s.eventChan <- event:
-	case <-s.ctx.Done():
+// seconds converts a time in seconds to a Duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// languageFor returns the transcription language for a requested language
+// tag. A language configured on the provider wins, otherwise the tag is
+// reduced to the ISO-639-1 code Whisper expects ("en-US" becomes "en").
+func (w *WhisperSTT) languageFor(tag string) string {
+	if w.language != "" {
+		return w.language
 	}
-}
\ No newline at end of file
+	lang, _, _ := strings.Cut(tag, "-")
+	return strings.ToLower(lang)
+}
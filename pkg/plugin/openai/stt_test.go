@@ -2,11 +2,15 @@ package openai
 
 import (
 	"context"
+	"encoding/json"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/chriscow/livekit-agents-go/pkg/ai/stt"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/vad"
 	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+	openai "github.com/sashabaranov/go-openai"
 )
 
 func TestWhisperSTT_Configuration(t *testing.T) {
@@ -118,10 +122,8 @@ func TestWhisperSTT_Stream(t *testing.T) {
 }
 
 func TestCombineFrames(t *testing.T) {
-	stream := &whisperStream{}
-	
 	// Test empty frames
-	_, err := stream.combineFrames([]rtc.AudioFrame{})
+	_, err := combineFrames([]rtc.AudioFrame{})
 	if err == nil {
 		t.Error("Expected error for empty frames")
 	}
@@ -142,7 +144,7 @@ func TestCombineFrames(t *testing.T) {
 		},
 	}
 
-	combined, err := stream.combineFrames(frames)
+	combined, err := combineFrames(frames)
 	if err != nil {
 		t.Fatalf("Failed to combine frames: %v", err)
 	}
@@ -168,8 +170,6 @@ func TestCombineFrames(t *testing.T) {
 }
 
 func TestConvertToWAV(t *testing.T) {
-	stream := &whisperStream{}
-	
 	audio := &combinedAudio{
 		data:       []byte{0, 1, 2, 3, 4, 5, 6, 7},
 		sampleRate: 16000,
@@ -177,7 +177,7 @@ func TestConvertToWAV(t *testing.T) {
 		duration:   time.Second,
 	}
 
-	wavData, err := stream.convertToWAV(audio)
+	wavData, err := convertToWAV(audio)
 	if err != nil {
 		t.Fatalf("Failed to convert to WAV: %v", err)
 	}
@@ -211,4 +211,110 @@ func TestConvertToWAV(t *testing.T) {
 	if len(wavData) != expectedTotalSize {
 		t.Errorf("Expected WAV size %d, got %d", expectedTotalSize, len(wavData))
 	}
-}
\ No newline at end of file
+}
+func TestWhisperSTT_Language(t *testing.T) {
+	tests := []struct {
+		configured string
+		stream     string
+		want       string
+	}{
+		{"", "en-US", "en"},
+		{"", "PT-br", "pt"},
+		{"", "", ""},
+		{"fr", "en-US", "fr"},
+	}
+
+	for _, tt := range tests {
+		w := &WhisperSTT{language: tt.configured}
+		if got := w.languageFor(tt.stream); got != tt.want {
+			t.Errorf("languageFor() with provider %q and stream %q = %q, want %q", tt.configured, tt.stream, got, tt.want)
+		}
+	}
+}
+
+// narrowbandVAD is a VAD that only accepts 16 kHz audio.
+type narrowbandVAD struct{}
+
+func (narrowbandVAD) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-chan vad.VADEvent, error) {
+	events := make(chan vad.VADEvent)
+	close(events)
+	return events, nil
+}
+
+func (narrowbandVAD) Capabilities() vad.VADCapabilities {
+	return vad.VADCapabilities{SampleRates: []int{16000}}
+}
+
+func TestWhisperSTT_CapabilitiesWithVAD(t *testing.T) {
+	whisper, err := NewWhisperSTT(Config{APIKey: "test-key", VAD: narrowbandVAD{}})
+	if err != nil {
+		t.Fatalf("Failed to create WhisperSTT: %v", err)
+	}
+
+	caps := whisper.Capabilities()
+	if len(caps.SampleRates) != 1 || caps.SampleRates[0] != 16000 {
+		t.Errorf("Expected only the VAD's 16 kHz sample rate, got %v", caps.SampleRates)
+	}
+	if len(caps.SupportedLanguages) == 0 {
+		t.Error("Expected supported languages to be populated")
+	}
+}
+
+func TestWhisperSTT_DefaultVAD(t *testing.T) {
+	whisper, err := NewWhisperSTT(Config{APIKey: "test-key"})
+	if err != nil {
+		t.Fatalf("Failed to create WhisperSTT: %v", err)
+	}
+
+	if _, ok := whisper.vad.(*vad.EnergyVAD); !ok {
+		t.Errorf("Expected an energy VAD by default, got %T", whisper.vad)
+	}
+	if caps := whisper.Capabilities(); len(caps.SampleRates) != 4 {
+		t.Errorf("Expected the energy VAD to accept all Whisper sample rates, got %v", caps.SampleRates)
+	}
+}
+
+func TestLanguageCode(t *testing.T) {
+	tests := map[string]string{
+		"english":        "en",
+		"Japanese":       "ja",
+		"haitian creole": "ht",
+		"en":             "en",
+		"klingon":        "",
+		"":               "",
+	}
+
+	for language, want := range tests {
+		if got := languageCode(language); got != want {
+			t.Errorf("languageCode(%q) = %q, want %q", language, got, want)
+		}
+	}
+}
+
+func TestNewRecognition(t *testing.T) {
+	var response openai.AudioResponse
+	err := json.Unmarshal([]byte(`{
+		"language": "english",
+		"text": " Hello world",
+		"segments": [{"avg_logprob": -0.1}, {"avg_logprob": -0.3}],
+		"words": [{"word": "Hello", "start": 0.0, "end": 0.4}, {"word": "world", "start": 0.5, "end": 1.25}]
+	}`), &response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	result := newRecognition(response, "")
+	if result.Text != "Hello world" || result.Language != "en" {
+		t.Errorf("Unexpected transcript %q in %q", result.Text, result.Language)
+	}
+	if len(result.Words) != 2 || result.Words[1].Start != 500*time.Millisecond || result.Words[1].End != 1250*time.Millisecond {
+		t.Errorf("Unexpected words %+v", result.Words)
+	}
+	if want := float32(math.Exp(-0.2)); math.Abs(float64(result.Confidence-want)) > 1e-6 {
+		t.Errorf("Expected confidence %v, got %v", want, result.Confidence)
+	}
+
+	if result := newRecognition(response, "de"); result.Language != "de" {
+		t.Errorf("Expected the requested language, got %q", result.Language)
+	}
+}
@@ -13,6 +13,9 @@ import (
 	openai "github.com/sashabaranov/go-openai"
 )
 
+// pcmSampleRate is the sample rate of OpenAI's raw PCM speech output.
+const pcmSampleRate = 24000
+
 // OpenAITTS implements the TTS interface using OpenAI's text-to-speech API
 type OpenAITTS struct {
 	client *openai.Client
@@ -45,86 +48,130 @@ func newOpenAITTS(config map[string]any) (any, error) {
 		voice = "alloy" // default voice
 	}
 	
+	clientConfig := openai.DefaultConfig(apiKey)
+	if baseURL, ok := config["base_url"].(string); ok && baseURL != "" {
+		clientConfig.BaseURL = baseURL
+	}
+	
 	return &OpenAITTS{
-		client: openai.NewClient(apiKey),
+		client: openai.NewClientWithConfig(clientConfig),
 		model:  model,
 		voice:  voice,
 	}, nil
 }
 
-// Synthesize converts text to audio frames using OpenAI TTS
+// Synthesize converts text to audio frames using OpenAI TTS. The speech is
+// requested as raw PCM and cut into 10 ms frames at 24 kHz. The request is sent
+// before Synthesize returns, so API errors are returned to the caller and
+// classified as recoverable or fatal. Use SynthesizeStream to also learn about
+// errors reading the audio.
 func (o *OpenAITTS) Synthesize(ctx context.Context, req tts.SynthesizeRequest) (<-chan rtc.AudioFrame, error) {
-	log.Printf("🔊 Starting OpenAI TTS synthesis (model: %s, voice: %s)", o.model, o.getVoice(req.Voice))
 	start := time.Now()
-	
-	// Create a channel for audio frames
+	resp, err := o.requestSpeech(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	frameChan := make(chan rtc.AudioFrame, 10)
-	
 	go func() {
 		defer close(frameChan)
-		
-		// Use voice from request or default
-		voice := o.getVoice(req.Voice)
-		
-		// Create TTS request
-		ttsReq := openai.CreateSpeechRequest{
-			Model: openai.SpeechModel(o.model),
-			Input: req.Text,
-			Voice: openai.SpeechVoice(voice),
-		}
-		
-		// Apply speed if specified
-		if req.Speed > 0 {
-			ttsReq.Speed = float64(req.Speed)
-		}
-		
-		resp, err := o.client.CreateSpeech(ctx, ttsReq)
-		if err != nil {
-			log.Printf("❌ OpenAI TTS failed: %v", err)
-			return
-		}
 		defer resp.Close()
-		
-		// Read audio data in chunks and convert to frames
-		const chunkSize = 1024 // 16-bit samples
-		buffer := make([]byte, chunkSize*2) // *2 for 16-bit samples
-		
-		for {
-			n, err := resp.Read(buffer)
-			if n > 0 {
-				// Create audio frame - OpenAI returns MP3, but we need PCM
-				// For now, we'll pass the raw data and let the consumer handle decoding
-				frame := rtc.AudioFrame{
-					Data:              append([]byte(nil), buffer[:n]...),
-					SampleRate:        24000, // OpenAI TTS default sample rate
-					SamplesPerChannel: n / 2, // 16-bit samples
-					NumChannels:       1,     // mono
-					Timestamp:         time.Since(start),
-				}
-				
-				select {
-				case frameChan <- frame:
-				case <-ctx.Done():
-					return
-				}
-			}
-			
-			if err != nil {
-				if err == io.EOF {
-					break
-				}
-				log.Printf("❌ Error reading TTS response: %v", err)
-				return
-			}
+
+		if err := readSpeech(ctx, resp, frameChan); err == nil {
+			log.Printf("✅ OpenAI TTS synthesis completed (duration: %v)", time.Since(start))
 		}
-		
-		duration := time.Since(start)
-		log.Printf("✅ OpenAI TTS synthesis completed (duration: %v)", duration)
 	}()
-	
+
 	return frameChan, nil
 }
 
+// SynthesizeStream opens a session that synthesizes pushed text sentence by
+// sentence like Synthesize. Errors reading the audio stop the session and are
+// reported in its result. Word timings are estimated from the speed.
+func (o *OpenAITTS) SynthesizeStream(ctx context.Context, req tts.SynthesizeRequest) (tts.SynthesizeStream, error) {
+	return tts.NewSentenceStream(ctx, req, tts.WithEstimatedTimings(o.synthesizeSentence)), nil
+}
+
+// synthesizeSentence synthesizes a sentence of a session into frames.
+func (o *OpenAITTS) synthesizeSentence(ctx context.Context, req tts.SynthesizeRequest, frames chan<- rtc.AudioFrame, words chan<- tts.WordTiming) error {
+	start := time.Now()
+	resp, err := o.requestSpeech(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	if err := readSpeech(ctx, resp, frames); err != nil {
+		return err
+	}
+	log.Printf("✅ OpenAI TTS synthesis completed (duration: %v)", time.Since(start))
+	return nil
+}
+
+// requestSpeech sends the speech request for req and returns the PCM response.
+func (o *OpenAITTS) requestSpeech(ctx context.Context, req tts.SynthesizeRequest) (io.ReadCloser, error) {
+	voice := o.getVoice(req.Voice)
+	log.Printf("🔊 Starting OpenAI TTS synthesis (model: %s, voice: %s)", o.model, voice)
+
+	ttsReq := openai.CreateSpeechRequest{
+		Model:          openai.SpeechModel(o.model),
+		Input:          req.Text,
+		Voice:          openai.SpeechVoice(voice),
+		ResponseFormat: openai.SpeechResponseFormatPcm,
+	}
+
+	// Apply speed if specified
+	if req.Speed > 0 {
+		ttsReq.Speed = float64(req.Speed)
+	}
+
+	resp, err := o.client.CreateSpeech(ctx, ttsReq)
+	if err != nil {
+		log.Printf("❌ OpenAI TTS failed: %v", err)
+		return nil, classifyError(err, "OpenAI TTS request failed")
+	}
+	return resp, nil
+}
+
+// readSpeech reads a PCM response into frames until it ends. It returns why
+// reading stopped early, classified as recoverable or fatal.
+func readSpeech(ctx context.Context, resp io.Reader, frames chan<- rtc.AudioFrame) error {
+	// PCM responses are 16-bit little-endian mono at 24 kHz
+	stream, err := rtc.NewAudioByteStream(pcmSampleRate, 1)
+	if err != nil {
+		return err
+	}
+
+	send := func(out []rtc.AudioFrame) error {
+		for _, frame := range out {
+			select {
+			case frames <- frame:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	}
+
+	buffer := make([]byte, 4096)
+	for {
+		n, err := resp.Read(buffer)
+		if n > 0 {
+			if err := send(stream.Write(buffer[:n])); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("❌ Error reading TTS response: %v", err)
+			return classifyError(err, "failed to read OpenAI TTS audio")
+		}
+	}
+	return send(stream.Flush(true))
+}
+
 // getVoice returns the voice to use, preferring request voice over default
 func (o *OpenAITTS) getVoice(requestVoice string) string {
 	if requestVoice != "" {
@@ -139,7 +186,7 @@ func (o *OpenAITTS) Capabilities() tts.TTSCapabilities {
 		Streaming:            false, // Not implementing real streaming yet
 		SupportedLanguages:   []string{"en", "es", "fr", "de", "it", "pt", "ru", "ja", "ko", "zh"}, // Approximate list
 		SupportedVoices:      []string{"alloy", "echo", "fable", "onyx", "nova", "shimmer"},
-		SampleRates:         []int{24000, 22050},
+		SampleRates:         []int{pcmSampleRate},
 		SupportsSSML:        false,
 		SupportsSpeedControl: true,
 		SupportsPitchControl: false,
@@ -0,0 +1,168 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/tts"
+)
+
+// newTestTTS creates an OpenAI TTS that talks to a test server.
+func newTestTTS(t *testing.T, handler http.HandlerFunc) *OpenAITTS {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	provider, err := newOpenAITTS(map[string]any{"api_key": "test-key", "base_url": server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create OpenAI TTS: %v", err)
+	}
+	return provider.(*OpenAITTS)
+}
+
+func TestOpenAITTS_SynthesizePCM(t *testing.T) {
+	var request map[string]any
+	provider := newTestTTS(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/audio/speech" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+
+		// 25 ms of audio written in uneven chunks
+		audio := make([]byte, 1200)
+		for i := range audio {
+			audio[i] = 1
+		}
+		w.Write(audio[:333])
+		w.(http.Flusher).Flush()
+		w.Write(audio[333:])
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	frames, err := provider.Synthesize(ctx, tts.SynthesizeRequest{Text: "Hello", Voice: "nova", Speed: 1.5})
+	if err != nil {
+		t.Fatalf("Synthesize failed: %v", err)
+	}
+
+	var count int
+	for frame := range frames {
+		if frame.SampleRate != 24000 || frame.NumChannels != 1 || frame.SamplesPerChannel != 240 || len(frame.Data) != 480 {
+			t.Errorf("Frame %d is not a 10ms 24kHz frame: %d Hz, %d bytes", count, frame.SampleRate, len(frame.Data))
+		}
+		if want := time.Duration(count) * 10 * time.Millisecond; frame.Timestamp != want {
+			t.Errorf("Frame %d timestamp = %v, want %v", count, frame.Timestamp, want)
+		}
+		count++
+	}
+
+	// The last 5 ms are padded to a full frame
+	if count != 3 {
+		t.Errorf("Expected 3 frames, got %d", count)
+	}
+	if request["response_format"] != "pcm" || request["voice"] != "nova" || request["speed"] != 1.5 {
+		t.Errorf("Unexpected request: %v", request)
+	}
+}
+
+func TestOpenAITTS_SynthesizeErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		recoverable bool
+	}{
+		{"invalid key", http.StatusUnauthorized, false},
+		{"bad request", http.StatusBadRequest, false},
+		{"rate limited", http.StatusTooManyRequests, true},
+		{"server error", http.StatusInternalServerError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestTTS(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"error": {"message": "nope", "type": "test"}}`))
+			})
+
+			frames, err := provider.Synthesize(context.Background(), tts.SynthesizeRequest{Text: "Hello"})
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if frames != nil {
+				t.Error("Expected no frame channel with an error")
+			}
+			if ai.IsRecoverable(err) != tt.recoverable || ai.IsFatal(err) == tt.recoverable {
+				t.Errorf("Expected recoverable=%v, got %v", tt.recoverable, err)
+			}
+		})
+	}
+}
+
+func TestOpenAITTS_SynthesizeStreamResult(t *testing.T) {
+	provider := newTestTTS(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(make([]byte, 1200))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := provider.SynthesizeStream(ctx, tts.SynthesizeRequest{})
+	if err != nil {
+		t.Fatalf("SynthesizeStream failed: %v", err)
+	}
+	stream.PushText("Héllo")
+	stream.Close()
+	for range stream.Frames() {
+	}
+
+	res := stream.Result()
+	if res.Err != nil || res.Characters != 5 || res.AudioDuration != 30*time.Millisecond {
+		t.Errorf("Unexpected result: %+v", res)
+	}
+}
+
+func TestOpenAITTS_SynthesizeStreamReadError(t *testing.T) {
+	provider := newTestTTS(t, func(w http.ResponseWriter, r *http.Request) {
+		// The connection is closed before the announced length was sent
+		w.Header().Set("Content-Length", "4800")
+		w.Write(make([]byte, 960))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := provider.SynthesizeStream(ctx, tts.SynthesizeRequest{})
+	if err != nil {
+		t.Fatalf("SynthesizeStream failed: %v", err)
+	}
+	stream.PushText("Hello")
+	stream.Close()
+	var count int
+	for range stream.Frames() {
+		count++
+	}
+
+	res := stream.Result()
+	if !ai.IsRecoverable(res.Err) {
+		t.Errorf("Expected a recoverable read error, got %v", res.Err)
+	}
+	if res.AudioDuration != time.Duration(count)*10*time.Millisecond {
+		t.Errorf("Expected the duration of the %d frames sent, got %v", count, res.AudioDuration)
+	}
+}
+
+func TestOpenAITTS_Capabilities(t *testing.T) {
+	provider := newTestTTS(t, func(w http.ResponseWriter, r *http.Request) {})
+	caps := provider.Capabilities()
+	if len(caps.SampleRates) != 1 || caps.SampleRates[0] != 24000 {
+		t.Errorf("Expected only the 24kHz PCM rate, got %v", caps.SampleRates)
+	}
+}
@@ -3,6 +3,7 @@ package silero
 import (
 	"os"
 	"path/filepath"
+	"time"
 )
 
 const (
@@ -10,8 +11,61 @@ const (
 	ModelFileName = "silero_vad.onnx"
 	// DefaultThreshold is the default VAD threshold
 	DefaultThreshold = 0.5
+	// DefaultMinSpeechDuration is the default speech needed to start a segment
+	DefaultMinSpeechDuration = 50 * time.Millisecond
+	// DefaultMinSilenceDuration is the default silence needed to end a segment
+	DefaultMinSilenceDuration = 550 * time.Millisecond
+	// DefaultPrefixPadding is the default audio kept before the start of speech
+	DefaultPrefixPadding = 500 * time.Millisecond
 )
 
+// Config holds configuration for Silero VAD.
+type Config struct {
+	Threshold  float32 `json:"threshold"`  // Speech probability that starts speech (0.0 to 1.0)
+	SampleRate int     `json:"sampleRate"` // Audio sample rate, 8000 or 16000 for the ONNX model
+	ModelPath  string  `json:"modelPath"`  // Path to ONNX model file
+
+	// DeactivationThreshold is the speech probability below which audio counts
+	// as silence (optional, defaults to Threshold - 0.15). Probabilities between
+	// the two thresholds keep the current state.
+	DeactivationThreshold float32 `json:"deactivationThreshold"`
+
+	// MinSpeechDuration is how long speech must last before it starts a
+	// segment (optional, defaults to 50ms)
+	MinSpeechDuration time.Duration `json:"minSpeechDuration"`
+
+	// MinSilenceDuration is how long silence must last before it ends a
+	// segment (optional, defaults to 550ms)
+	MinSilenceDuration time.Duration `json:"minSilenceDuration"`
+
+	// PrefixPadding is how much audio before the detected start of speech
+	// belongs to the segment (optional, defaults to 500ms)
+	PrefixPadding time.Duration `json:"prefixPadding"`
+}
+
+// withDefaults returns the config with defaults for unset fields.
+func (c Config) withDefaults() Config {
+	if c.Threshold <= 0 {
+		c.Threshold = DefaultThreshold
+	}
+	if c.SampleRate <= 0 {
+		c.SampleRate = 16000
+	}
+	if c.DeactivationThreshold <= 0 {
+		c.DeactivationThreshold = max(c.Threshold-0.15, 0.01)
+	}
+	if c.MinSpeechDuration <= 0 {
+		c.MinSpeechDuration = DefaultMinSpeechDuration
+	}
+	if c.MinSilenceDuration <= 0 {
+		c.MinSilenceDuration = DefaultMinSilenceDuration
+	}
+	if c.PrefixPadding <= 0 {
+		c.PrefixPadding = DefaultPrefixPadding
+	}
+	return c
+}
+
 // getDefaultModelPath returns the default path for the Silero model.
 func getDefaultModelPath() string {
 	modelPath := os.Getenv("LK_MODEL_PATH")
@@ -20,4 +74,4 @@ func getDefaultModelPath() string {
 		modelPath = filepath.Join(homeDir, ".livekit", "models")
 	}
 	return filepath.Join(modelPath, ModelFileName)
-}
\ No newline at end of file
+}
@@ -0,0 +1,284 @@
+package silero
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/vad"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// speechModel predicts the probability of speech in consecutive windows of
+// audio. Like the Silero LSTM, it may carry state from one window to the next,
+// so each detection stream uses its own model.
+type speechModel interface {
+	// Predict returns the speech probability of a window of samples in [-1, 1]
+	Predict(window []float32) (float32, error)
+
+	// Close releases the model's resources
+	Close() error
+}
+
+// windowSize returns the number of samples the Silero v5 model expects per
+// window at sampleRate, or 0 if the model does not support the rate.
+func windowSize(sampleRate int) int {
+	switch sampleRate {
+	case 16000:
+		return 512
+	case 8000:
+		return 256
+	default:
+		return 0
+	}
+}
+
+// transition is a change of speech state reported by the segmenter.
+type transition int
+
+const (
+	noTransition transition = iota
+	speechStarted
+	speechEnded
+)
+
+// segmenter turns speech probabilities, one per window, into speech segments
+// using hysteresis: speech starts once the probability stays at or above
+// Threshold for MinSpeechDuration, and ends once it stays below
+// DeactivationThreshold for MinSilenceDuration.
+type segmenter struct {
+	cfg    Config
+	window time.Duration
+
+	position   time.Duration // audio processed so far
+	speaking   bool
+	speechRun  time.Duration // consecutive speech while not speaking
+	runStart   time.Duration // where the current run of speech began
+	silenceRun time.Duration // consecutive silence while speaking
+
+	// Start of the current or last segment in the audio, including the
+	// prefix padding, and end of the last segment
+	speechStart time.Duration
+	speechEnd   time.Duration
+}
+
+// newSegmenter creates a segmenter for windows of the given duration.
+func newSegmenter(cfg Config, window time.Duration) *segmenter {
+	return &segmenter{cfg: cfg, window: window}
+}
+
+// push records the probability of the next window and reports whether
+// speech started or ended with it.
+func (s *segmenter) push(probability float32) transition {
+	s.position += s.window
+
+	if !s.speaking {
+		switch {
+		case probability >= s.cfg.Threshold:
+			if s.speechRun == 0 {
+				s.runStart = s.position - s.window
+			}
+			s.speechRun += s.window
+		case probability < s.cfg.DeactivationThreshold:
+			s.speechRun = 0
+		}
+		if s.speechRun < s.cfg.MinSpeechDuration {
+			return noTransition
+		}
+		s.speaking = true
+		s.silenceRun = 0
+		s.speechStart = max(s.runStart-s.cfg.PrefixPadding, 0)
+		return speechStarted
+	}
+
+	if probability >= s.cfg.DeactivationThreshold {
+		s.silenceRun = 0
+		return noTransition
+	}
+	s.silenceRun += s.window
+	if s.silenceRun < s.cfg.MinSilenceDuration {
+		return noTransition
+	}
+	s.speaking = false
+	s.speechRun = 0
+	s.speechEnd = s.position - s.silenceRun
+	return speechEnded
+}
+
+// speechDuration returns the speech in the current segment, or in the current
+// run of speech if there is no segment in progress.
+func (s *segmenter) speechDuration() time.Duration {
+	if s.speaking {
+		return s.position - s.runStart
+	}
+	return s.speechRun
+}
+
+// silenceDuration returns the silence at the end of the current segment, or
+// since the last one ended.
+func (s *segmenter) silenceDuration() time.Duration {
+	if s.speaking {
+		return s.silenceRun
+	}
+	return s.position - s.speechEnd
+}
+
+// end ends the current segment, for when the input closes during speech.
+func (s *segmenter) end() {
+	s.speaking = false
+	s.speechRun = 0
+	s.speechEnd = s.position - s.silenceRun
+}
+
+// timedFrame is a frame of input with its offset in the audio.
+type timedFrame struct {
+	frame      rtc.AudioFrame
+	start, end time.Duration
+}
+
+// detectSpeech runs model over frames, one window at a time, and sends an
+// inference event per window and speech start and end events until frames
+// is closed or ctx is cancelled. Speech still in progress when frames is
+// closed is ended.
+//
+// The frames of the current segment are buffered, along with up to
+// PrefixPadding of audio before it, so that start and end events can carry
+// the speech audio.
+func detectSpeech(ctx context.Context, model speechModel, cfg Config, frames <-chan rtc.AudioFrame, events chan<- vad.VADEvent) {
+	size := windowSize(cfg.SampleRate)
+	seg := newSegmenter(cfg, time.Duration(size)*time.Second/time.Duration(cfg.SampleRate))
+	samples := make([]float32, 0, 2*size)
+
+	var buffered []timedFrame
+	var received time.Duration
+
+	send := func(event vad.VADEvent) bool {
+		event.Timestamp = time.Now()
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	sendError := func(err error) {
+		send(vad.VADEvent{Type: vad.VADEventError, Error: err})
+	}
+
+	// segmentEvent returns a start or end event for the current segment,
+	// splitting the buffered audio into padding and speech where the run of
+	// speech began
+	segmentEvent := func(eventType vad.VADEventType, speech time.Duration) vad.VADEvent {
+		event := vad.VADEvent{
+			Type:            eventType,
+			Speaking:        seg.speaking,
+			SpeechDuration:  speech,
+			SilenceDuration: seg.silenceRun,
+		}
+		for _, tf := range buffered {
+			if tf.end <= seg.runStart {
+				event.PrefixPadding = append(event.PrefixPadding, tf.frame)
+			} else {
+				event.Frames = append(event.Frames, tf.frame)
+			}
+		}
+		return event
+	}
+
+	// trim drops buffered frames that can no longer be part of a segment's
+	// audio while no speech is in progress
+	trim := func() {
+		if seg.speaking {
+			return
+		}
+		from := seg.position
+		if seg.speechRun > 0 {
+			from = seg.runStart
+		}
+		from -= cfg.PrefixPadding
+		i := 0
+		for i < len(buffered) && buffered[i].end <= from {
+			i++
+		}
+		buffered = buffered[:copy(buffered, buffered[i:])]
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case frame, ok := <-frames:
+			if !ok {
+				if seg.speaking {
+					seg.end()
+					send(segmentEvent(vad.VADEventSpeechEnd, seg.speechEnd-seg.runStart))
+				}
+				return
+			}
+			if frame.SampleRate != cfg.SampleRate {
+				err := fmt.Errorf("silero: got %d Hz audio, expected %d Hz", frame.SampleRate, cfg.SampleRate)
+				sendError(ai.NewFatalError(err, "unsupported sample rate"))
+				return
+			}
+
+			count := len(samples)
+			samples = appendSamples(samples, frame)
+			duration := time.Duration(len(samples)-count) * time.Second / time.Duration(cfg.SampleRate)
+			buffered = append(buffered, timedFrame{frame: frame, start: received, end: received + duration})
+			received += duration
+
+			for len(samples) >= size {
+				started := time.Now()
+				probability, err := model.Predict(samples[:size])
+				if err != nil {
+					sendError(ai.NewRecoverableError(err, "silero inference failed"))
+					return
+				}
+				elapsed := time.Since(started)
+				samples = samples[:copy(samples, samples[size:])]
+
+				change := seg.push(probability)
+				if !send(vad.VADEvent{
+					Type:              vad.VADEventInferenceDone,
+					Probability:       probability,
+					InferenceDuration: elapsed,
+					Speaking:          seg.speaking,
+					SpeechDuration:    seg.speechDuration(),
+					SilenceDuration:   seg.silenceDuration(),
+				}) {
+					return
+				}
+
+				switch change {
+				case speechStarted:
+					if !send(segmentEvent(vad.VADEventSpeechStart, seg.speechDuration())) {
+						return
+					}
+				case speechEnded:
+					if !send(segmentEvent(vad.VADEventSpeechEnd, seg.speechEnd-seg.runStart)) {
+						return
+					}
+				}
+				trim()
+			}
+		}
+	}
+}
+
+// appendSamples appends the 16-bit PCM samples of frame to samples as floats
+// in [-1, 1], mixing multiple channels down to mono.
+func appendSamples(samples []float32, frame rtc.AudioFrame) []float32 {
+	channels := max(frame.NumChannels, 1)
+	frameSamples := len(frame.Data) / (2 * channels)
+	for i := 0; i < frameSamples; i++ {
+		var sum float32
+		for c := 0; c < channels; c++ {
+			offset := 2 * (i*channels + c)
+			sum += float32(int16(binary.LittleEndian.Uint16(frame.Data[offset:]))) / 32768
+		}
+		samples = append(samples, sum/float32(channels))
+	}
+	return samples
+}
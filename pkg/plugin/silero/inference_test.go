@@ -0,0 +1,246 @@
+package silero
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
+	"github.com/chriscow/livekit-agents-go/pkg/ai/vad"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// scriptedModel returns queued probabilities and records the windows it saw.
+type scriptedModel struct {
+	probabilities []float32
+	windows       [][]float32
+}
+
+func (m *scriptedModel) Predict(window []float32) (float32, error) {
+	m.windows = append(m.windows, append([]float32(nil), window...))
+	if len(m.probabilities) == 0 {
+		return 0, nil
+	}
+	p := m.probabilities[0]
+	m.probabilities = m.probabilities[1:]
+	return p, nil
+}
+
+func (m *scriptedModel) Close() error { return nil }
+
+// window is the duration of a 512 sample window at 16 kHz.
+const window = 32 * time.Millisecond
+
+func TestSegmenter_Hysteresis(t *testing.T) {
+	cfg := Config{
+		Threshold:          0.5,
+		MinSpeechDuration:  2 * window,
+		MinSilenceDuration: 3 * window,
+		PrefixPadding:      window,
+	}.withDefaults()
+	seg := newSegmenter(cfg, window)
+
+	tests := []struct {
+		probability float32
+		want        transition
+	}{
+		{0.9, noTransition},  // speech shorter than MinSpeechDuration
+		{0.1, noTransition},  // silence resets it
+		{0.9, noTransition},  // speech starts at window 2
+		{0.4, noTransition},  // between thresholds, neither speech nor silence
+		{0.8, speechStarted}, // second window of speech
+		{0.2, noTransition},
+		{0.2, noTransition},
+		{0.4, noTransition}, // above the deactivation threshold, silence resets
+		{0.2, noTransition},
+		{0.2, noTransition},
+		{0.2, speechEnded}, // third window of silence
+	}
+	for i, tt := range tests {
+		if got := seg.push(tt.probability); got != tt.want {
+			t.Fatalf("window %d: expected transition %d, got %d", i, tt.want, got)
+		}
+	}
+
+	// Speech ran from window 2, one window of padding earlier
+	if seg.speechStart != window {
+		t.Errorf("expected the segment to start at %v, got %v", window, seg.speechStart)
+	}
+	if seg.speechEnd != 8*window {
+		t.Errorf("expected the segment to end at %v, got %v", 8*window, seg.speechEnd)
+	}
+}
+
+func TestConfig_Defaults(t *testing.T) {
+	cfg := Config{Threshold: 0.6}.withDefaults()
+	if cfg.DeactivationThreshold < 0.449 || cfg.DeactivationThreshold > 0.451 {
+		t.Errorf("expected a deactivation threshold of 0.45, got %v", cfg.DeactivationThreshold)
+	}
+	if cfg.SampleRate != 16000 || cfg.MinSpeechDuration != DefaultMinSpeechDuration ||
+		cfg.MinSilenceDuration != DefaultMinSilenceDuration || cfg.PrefixPadding != DefaultPrefixPadding {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+}
+
+// pcmFrame returns a 10ms frame of 16 kHz mono audio at a constant level.
+func pcmFrame(level int16) rtc.AudioFrame {
+	data := make([]byte, 320)
+	for i := 0; i < 160; i++ {
+		binary.LittleEndian.PutUint16(data[2*i:], uint16(level))
+	}
+	return rtc.AudioFrame{Data: data, SampleRate: 16000, NumChannels: 1, SamplesPerChannel: 160}
+}
+
+func TestDetectSpeech_Windows(t *testing.T) {
+	model := &scriptedModel{probabilities: []float32{0.9, 0.9, 0.9}}
+	cfg := Config{MinSpeechDuration: 2 * window}.withDefaults()
+
+	// 10 frames of 160 samples make three full windows of 512
+	frames := make(chan rtc.AudioFrame, 10)
+	for i := 0; i < 10; i++ {
+		frames <- pcmFrame(16384)
+	}
+	close(frames)
+
+	events := make(chan vad.VADEvent, 10)
+	detectSpeech(context.Background(), model, cfg, frames, events)
+	close(events)
+
+	if len(model.windows) != 3 {
+		t.Fatalf("expected 3 windows, got %d", len(model.windows))
+	}
+	for i, w := range model.windows {
+		if len(w) != 512 || w[0] != 0.5 {
+			t.Errorf("window %d: expected 512 samples at 0.5, got %d samples starting at %v", i, len(w), w[0])
+		}
+	}
+
+	var types []vad.VADEventType
+	inferences := 0
+	for event := range events {
+		if event.Type == vad.VADEventInferenceDone {
+			inferences++
+			continue
+		}
+		types = append(types, event.Type)
+	}
+	if inferences != 3 {
+		t.Errorf("expected an inference event per window, got %d", inferences)
+	}
+	if len(types) != 2 || types[0] != vad.VADEventSpeechStart || types[1] != vad.VADEventSpeechEnd {
+		t.Errorf("expected speech to start and end when the input closed, got %v", types)
+	}
+}
+
+// windowFrame returns a frame of exactly one window, marked with a sample
+// level to identify it.
+func windowFrame(level int16) rtc.AudioFrame {
+	data := make([]byte, 1024)
+	for i := 0; i < 512; i++ {
+		binary.LittleEndian.PutUint16(data[2*i:], uint16(level))
+	}
+	return rtc.AudioFrame{Data: data, SampleRate: 16000, NumChannels: 1, SamplesPerChannel: 512}
+}
+
+// frameLevels returns the marker levels of frames.
+func frameLevels(frames []rtc.AudioFrame) []int16 {
+	var levels []int16
+	for _, f := range frames {
+		levels = append(levels, int16(binary.LittleEndian.Uint16(f.Data)))
+	}
+	return levels
+}
+
+func TestDetectSpeech_SpeechAudio(t *testing.T) {
+	probabilities := []float32{0, 0, 0, 0.9, 0.9, 0.9, 0.1, 0.1}
+	model := &scriptedModel{probabilities: append([]float32(nil), probabilities...)}
+	cfg := Config{
+		MinSpeechDuration:  2 * window,
+		MinSilenceDuration: 2 * window,
+		PrefixPadding:      2 * window,
+	}.withDefaults()
+
+	frames := make(chan rtc.AudioFrame, len(probabilities))
+	for i := range probabilities {
+		frames <- windowFrame(int16(i))
+	}
+	close(frames)
+
+	events := make(chan vad.VADEvent, 2*len(probabilities))
+	detectSpeech(context.Background(), model, cfg, frames, events)
+	close(events)
+
+	var inferred []float32
+	var segments []vad.VADEvent
+	for event := range events {
+		if event.Type == vad.VADEventInferenceDone {
+			inferred = append(inferred, event.Probability)
+		} else {
+			segments = append(segments, event)
+		}
+	}
+
+	if len(inferred) != len(probabilities) {
+		t.Fatalf("expected %d inference events, got %d", len(probabilities), len(inferred))
+	}
+	for i, p := range inferred {
+		if p != probabilities[i] {
+			t.Errorf("inference %d: expected probability %v, got %v", i, probabilities[i], p)
+		}
+	}
+	if len(segments) != 2 {
+		t.Fatalf("expected speech start and end events, got %+v", segments)
+	}
+
+	// Speech began at window 3 and was detected at window 4, with two
+	// windows of padding before it
+	start := segments[0]
+	if start.Type != vad.VADEventSpeechStart || !start.Speaking || start.SpeechDuration != 2*window {
+		t.Errorf("unexpected start event: %+v", start)
+	}
+	if got := frameLevels(start.PrefixPadding); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("expected windows 1 and 2 as padding, got %v", got)
+	}
+	if got := frameLevels(start.Frames); len(got) != 2 || got[0] != 3 || got[1] != 4 {
+		t.Errorf("expected windows 3 and 4 as speech, got %v", got)
+	}
+
+	// Speech ended after two windows of silence
+	end := segments[1]
+	if end.Type != vad.VADEventSpeechEnd || end.Speaking ||
+		end.SpeechDuration != 3*window || end.SilenceDuration != 2*window {
+		t.Errorf("unexpected end event: %+v", end)
+	}
+	if got := frameLevels(end.Frames); len(got) != 5 || got[0] != 3 || got[4] != 7 {
+		t.Errorf("expected windows 3 to 7 as speech, got %v", got)
+	}
+	if got := frameLevels(end.Audio()); len(got) != 7 || got[0] != 1 {
+		t.Errorf("expected the audio to include the padding, got %v", got)
+	}
+}
+
+func TestDetectSpeech_WrongSampleRate(t *testing.T) {
+	frames := make(chan rtc.AudioFrame, 1)
+	frames <- rtc.AudioFrame{Data: make([]byte, 480), SampleRate: 24000, NumChannels: 1, SamplesPerChannel: 240}
+	close(frames)
+
+	events := make(chan vad.VADEvent, 1)
+	detectSpeech(context.Background(), &scriptedModel{}, Config{}.withDefaults(), frames, events)
+	close(events)
+
+	event := <-events
+	if event.Type != vad.VADEventError || !ai.IsFatal(event.Error) {
+		t.Errorf("expected a fatal error event, got %+v", event)
+	}
+}
+
+func TestAppendSamples_MixesChannels(t *testing.T) {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint16(data[0:], uint16(16384))
+	binary.LittleEndian.PutUint16(data[2:], 0)
+	samples := appendSamples(nil, rtc.AudioFrame{Data: data, SampleRate: 16000, NumChannels: 2, SamplesPerChannel: 1})
+	if len(samples) != 1 || samples[0] != 0.25 {
+		t.Errorf("expected a single mixed sample of 0.25, got %v", samples)
+	}
+}
@@ -0,0 +1,155 @@
+//go:build silero
+
+package silero
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// stateSize is the number of values in the Silero v5 LSTM state, shaped [2, 1, 128].
+const stateSize = 2 * 1 * 128
+
+var (
+	ortOnce    sync.Once
+	ortInitErr error
+)
+
+// ensureOrtEnv initializes the ONNX runtime environment once per process,
+// unless another package such as the turn detector already did.
+func ensureOrtEnv() error {
+	ortOnce.Do(func() {
+		if ort.IsInitialized() {
+			return
+		}
+		if libPath := os.Getenv("ONNXRUNTIME_LIB"); libPath != "" {
+			ort.SetSharedLibraryPath(libPath)
+		} else if runtime.GOOS == "darwin" {
+			// Default to Homebrew path on macOS as fallback
+			ort.SetSharedLibraryPath("/opt/homebrew/lib/libonnxruntime.dylib")
+		}
+		ortInitErr = ort.InitializeEnvironment()
+	})
+	return ortInitErr
+}
+
+// onnxModel runs the Silero v5 ONNX model. Each window is prefixed with the
+// last samples of the previous one, and the LSTM state output by one window
+// is fed into the next.
+type onnxModel struct {
+	session *ort.AdvancedSession
+
+	input      *ort.Tensor[float32] // [1, context + window]
+	state      *ort.Tensor[float32] // [2, 1, 128]
+	sampleRate *ort.Scalar[int64]
+	output     *ort.Tensor[float32] // [1, 1]
+	stateN     *ort.Tensor[float32] // [2, 1, 128]
+
+	contextSize int
+}
+
+// newONNXModel creates a model with a fresh state from the ONNX model data.
+func newONNXModel(modelData []byte, sampleRate int) (*onnxModel, error) {
+	size := windowSize(sampleRate)
+	if size == 0 {
+		return nil, fmt.Errorf("silero model does not support %d Hz audio, use 8000 or 16000", sampleRate)
+	}
+	if err := ensureOrtEnv(); err != nil {
+		return nil, fmt.Errorf("failed to initialize ONNX runtime: %w", err)
+	}
+
+	// The model sees 64 samples of context at 16 kHz and 32 at 8 kHz
+	m := &onnxModel{contextSize: size / 8}
+	var err error
+	if m.input, err = ort.NewEmptyTensor[float32](ort.NewShape(1, int64(m.contextSize+size))); err != nil {
+		return nil, fmt.Errorf("failed to create input tensor: %w", err)
+	}
+	if m.state, err = ort.NewEmptyTensor[float32](ort.NewShape(2, 1, 128)); err != nil {
+		m.Close()
+		return nil, fmt.Errorf("failed to create state tensor: %w", err)
+	}
+	if m.sampleRate, err = ort.NewScalar(int64(sampleRate)); err != nil {
+		m.Close()
+		return nil, fmt.Errorf("failed to create sample rate tensor: %w", err)
+	}
+	if m.output, err = ort.NewEmptyTensor[float32](ort.NewShape(1, 1)); err != nil {
+		m.Close()
+		return nil, fmt.Errorf("failed to create output tensor: %w", err)
+	}
+	if m.stateN, err = ort.NewEmptyTensor[float32](ort.NewShape(2, 1, 128)); err != nil {
+		m.Close()
+		return nil, fmt.Errorf("failed to create state output tensor: %w", err)
+	}
+
+	options, err := ort.NewSessionOptions()
+	if err != nil {
+		m.Close()
+		return nil, fmt.Errorf("failed to create session options: %w", err)
+	}
+	defer options.Destroy()
+	// Windows are small, so a single thread avoids scheduling overhead
+	if err := options.SetIntraOpNumThreads(1); err != nil {
+		m.Close()
+		return nil, fmt.Errorf("failed to set intra-op threads: %w", err)
+	}
+	if err := options.SetInterOpNumThreads(1); err != nil {
+		m.Close()
+		return nil, fmt.Errorf("failed to set inter-op threads: %w", err)
+	}
+
+	m.session, err = ort.NewAdvancedSessionWithONNXData(modelData,
+		[]string{"input", "state", "sr"},
+		[]string{"output", "stateN"},
+		[]ort.Value{m.input, m.state, m.sampleRate},
+		[]ort.Value{m.output, m.stateN},
+		options)
+	if err != nil {
+		m.Close()
+		return nil, fmt.Errorf("failed to create ONNX session: %w", err)
+	}
+	return m, nil
+}
+
+// Predict returns the speech probability of window, which must hold a full
+// window of samples.
+func (m *onnxModel) Predict(window []float32) (float32, error) {
+	input := m.input.GetData()
+	copy(input[m.contextSize:], window)
+	if err := m.session.Run(); err != nil {
+		return 0, fmt.Errorf("ONNX inference failed: %w", err)
+	}
+
+	// Carry the state and the end of this window over to the next one
+	copy(m.state.GetData(), m.stateN.GetData())
+	copy(input[:m.contextSize], input[len(input)-m.contextSize:])
+	return m.output.GetData()[0], nil
+}
+
+// Close destroys the session and its tensors.
+func (m *onnxModel) Close() error {
+	if m.session != nil {
+		m.session.Destroy()
+	}
+	// Fields are checked one by one, since a nil pointer in an interface
+	// is not nil and a partly created model has some left unset
+	if m.input != nil {
+		m.input.Destroy()
+	}
+	if m.state != nil {
+		m.state.Destroy()
+	}
+	if m.sampleRate != nil {
+		m.sampleRate.Destroy()
+	}
+	if m.output != nil {
+		m.output.Destroy()
+	}
+	if m.stateN != nil {
+		m.stateN.Destroy()
+	}
+	return nil
+}
@@ -1,19 +1,19 @@
 // Package silero provides Silero VAD (Voice Activity Detection) implementation.
-// This plugin demonstrates ONNX model loading with fallback to energy-based VAD.
+// It runs the Silero v5 ONNX model and falls back to energy-based VAD when the
+// model is not available.
 //go:build silero
 
 package silero
 
 import (
 	"context"
-	"encoding/binary"
 	"fmt"
 	"log/slog"
-	"math"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/chriscow/livekit-agents-go/pkg/ai"
 	"github.com/chriscow/livekit-agents-go/pkg/ai/vad"
 	"github.com/chriscow/livekit-agents-go/pkg/plugin"
 	"github.com/chriscow/livekit-agents-go/pkg/rtc"
@@ -22,40 +22,22 @@ import (
 
 // SileroVAD implements VAD using the Silero ONNX model with energy-based fallback.
 type SileroVAD struct {
+	cfg           Config
 	threshold     float32
 	sampleRate    int
 	frameSize     int
 	useONNX       bool
 	modelPath     string
-	energyVAD     *EnergyVAD
-}
-
-// Config holds configuration for Silero VAD.
-type Config struct {
-	Threshold  float32 `json:"threshold"`  // VAD threshold (0.0 to 1.0)
-	SampleRate int     `json:"sampleRate"` // Audio sample rate
-	ModelPath  string  `json:"modelPath"`  // Path to ONNX model file
-}
-
-// EnergyVAD provides energy-based VAD fallback when ONNX is not available.
-type EnergyVAD struct {
-	threshold     float32
-	sampleRate    int
-	frameSize     int
-	silenceFrames int
-	speechFrames  int
+	newModel      func() (speechModel, error) // creates the model state of a detection stream
+	energyVAD     *vad.EnergyVAD
 }
 
 // NewSileroVAD creates a new Silero VAD instance.
 func NewSileroVAD(cfg Config) (*SileroVAD, error) {
-	if cfg.Threshold <= 0 {
-		cfg.Threshold = DefaultThreshold
-	}
-	if cfg.SampleRate <= 0 {
-		cfg.SampleRate = 16000
-	}
+	cfg = cfg.withDefaults()
 
 	s := &SileroVAD{
+		cfg:        cfg,
 		threshold:  cfg.Threshold,
 		sampleRate: cfg.SampleRate,
 		frameSize:  cfg.SampleRate / 100, // 10ms frames
@@ -82,132 +64,69 @@ func NewSileroVAD(cfg Config) (*SileroVAD, error) {
 	}
 
 	// Always create energy-based VAD as fallback
-	s.energyVAD = &EnergyVAD{
-		threshold:  cfg.Threshold,
-		sampleRate: cfg.SampleRate,
-		frameSize:  cfg.SampleRate / 100,
-	}
+	s.energyVAD = vad.NewEnergyVAD(cfg.Threshold, cfg.PrefixPadding)
 
 	return s, nil
 }
 
-// loadONNXModel loads the Silero ONNX model.
-// This is a placeholder - real implementation would use onnxruntime-go.
+// loadONNXModel loads the Silero ONNX model. A session is created to check
+// that the model is valid; each detection stream gets its own session since
+// the model carries state between windows.
 func (s *SileroVAD) loadONNXModel(modelPath string) error {
-	// TODO: ONNX integration tracked in GitHub issue #23
-	// For now, we'll simulate successful loading
+	modelData, err := os.ReadFile(modelPath)
+	if err != nil {
+		return fmt.Errorf("failed to read model: %w", err)
+	}
+
+	newModel := func() (speechModel, error) {
+		return newONNXModel(modelData, s.sampleRate)
+	}
+	model, err := newModel()
+	if err != nil {
+		return err
+	}
+	model.Close()
+
+	s.newModel = newModel
 	slog.Info("Loaded Silero ONNX model", slog.String("model_path", modelPath))
 	return nil
 }
 
 // Detect implements the VAD interface.
 func (s *SileroVAD) Detect(ctx context.Context, frames <-chan rtc.AudioFrame) (<-chan vad.VADEvent, error) {
+	if !s.useONNX {
+		return s.energyVAD.Detect(ctx, frames)
+	}
+
+	model, err := s.newModel()
+	if err != nil {
+		return nil, ai.NewRecoverableError(err, "failed to create Silero session")
+	}
+
 	eventChan := make(chan vad.VADEvent, 10)
 
 	go func() {
 		defer close(eventChan)
-
-		if s.useONNX {
-			s.detectWithONNX(ctx, frames, eventChan)
-		} else {
-			s.energyVAD.detect(ctx, frames, eventChan)
-		}
+		defer model.Close()
+		detectSpeech(ctx, model, s.cfg, frames, eventChan)
 	}()
 
 	return eventChan, nil
 }
 
-// detectWithONNX processes frames using the ONNX model.
-func (s *SileroVAD) detectWithONNX(ctx context.Context, frames <-chan rtc.AudioFrame, events chan<- vad.VADEvent) {
-	// TODO: ONNX VAD processing tracked in GitHub issue #23
-	// For now, fall back to energy-based detection
-	slog.Debug("Using ONNX-based VAD (placeholder implementation)")
-	s.energyVAD.detect(ctx, frames, events)
-}
-
-// detect implements energy-based VAD detection.
-func (e *EnergyVAD) detect(ctx context.Context, frames <-chan rtc.AudioFrame, events chan<- vad.VADEvent) {
-	var isSpeaking bool
-	consecutiveSilence := 0
-	consecutiveSpeech := 0
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case frame, ok := <-frames:
-			if !ok {
-				// If we were speaking, send speech end event
-				if isSpeaking {
-					events <- vad.VADEvent{
-						Type:      vad.VADEventSpeechEnd,
-						Timestamp: time.Now(),
-					}
-				}
-				return
-			}
-
-			energy := e.calculateRMSEnergy(frame.Data)
-			isVoiceFrame := energy > e.threshold
-
-			if isVoiceFrame {
-				consecutiveSpeech++
-				consecutiveSilence = 0
-
-				// Start of speech
-				if !isSpeaking && consecutiveSpeech >= 3 {
-					isSpeaking = true
-					events <- vad.VADEvent{
-						Type:      vad.VADEventSpeechStart,
-						Timestamp: time.Now(),
-					}
-				}
-			} else {
-				consecutiveSilence++
-				consecutiveSpeech = 0
-
-				// End of speech
-				if isSpeaking && consecutiveSilence >= 10 {
-					isSpeaking = false
-					events <- vad.VADEvent{
-						Type:      vad.VADEventSpeechEnd,
-						Timestamp: time.Now(),
-					}
-				}
-			}
-		}
-	}
-}
-
-// calculateRMSEnergy computes the RMS energy of an audio frame.
-func (e *EnergyVAD) calculateRMSEnergy(data []byte) float32 {
-	if len(data) < 2 {
-		return 0
-	}
-
-	var sum float64
-	samples := len(data) / 2 // 16-bit samples
-
-	for i := 0; i < samples; i++ {
-		// Convert bytes to 16-bit signed integer using little-endian
-		sample := int16(binary.LittleEndian.Uint16(data[i*2 : i*2+2]))
-		sum += float64(sample * sample)
-	}
-
-	// Calculate actual RMS (root mean square)
-	meanSquare := sum / float64(samples)
-	rms := math.Sqrt(meanSquare)
-	return float32(rms) / 32768.0 // Normalize to 0-1 range
-}
-
 // Capabilities returns the VAD capabilities.
 func (s *SileroVAD) Capabilities() vad.VADCapabilities {
-	return vad.VADCapabilities{
-		SampleRates:        []int{8000, 16000, 48000},
+	caps := vad.VADCapabilities{
+		SampleRates:        []int{s.sampleRate}, // Frames must match the configured rate
 		MinSpeechDuration:  100 * time.Millisecond,
 		MinSilenceDuration: 300 * time.Millisecond,
 		Sensitivity:        s.threshold,
 	}
+	if s.useONNX {
+		caps.MinSpeechDuration = s.cfg.MinSpeechDuration
+		caps.MinSilenceDuration = s.cfg.MinSilenceDuration
+	}
+	return caps
 }
 
 
@@ -255,6 +174,19 @@ func newSileroVAD(cfg map[string]any) (any, error) {
 	if modelPath, ok := cfg["modelPath"].(string); ok {
 		config.ModelPath = modelPath
 	}
+	if threshold, ok := cfg["deactivationThreshold"].(float64); ok {
+		config.DeactivationThreshold = float32(threshold)
+	}
+	// Durations are given in seconds
+	if seconds, ok := cfg["minSpeechDuration"].(float64); ok {
+		config.MinSpeechDuration = time.Duration(seconds * float64(time.Second))
+	}
+	if seconds, ok := cfg["minSilenceDuration"].(float64); ok {
+		config.MinSilenceDuration = time.Duration(seconds * float64(time.Second))
+	}
+	if seconds, ok := cfg["prefixPadding"].(float64); ok {
+		config.PrefixPadding = time.Duration(seconds * float64(time.Second))
+	}
 
 	return NewSileroVAD(config)
 }
@@ -267,9 +199,12 @@ func init() {
 		Description: "Silero VAD with ONNX model and energy-based fallback",
 		Version:     "1.0.0",
 		Config: map[string]interface{}{
-			"threshold":  DefaultThreshold,
-			"sampleRate": 16000,
-			"modelPath":  "",
+			"threshold":          DefaultThreshold,
+			"sampleRate":         16000,
+			"modelPath":          "",
+			"minSpeechDuration":  DefaultMinSpeechDuration.Seconds(),
+			"minSilenceDuration": DefaultMinSilenceDuration.Seconds(),
+			"prefixPadding":      DefaultPrefixPadding.Seconds(),
 		},
 		Downloader: &SileroDownloader{},
 	})
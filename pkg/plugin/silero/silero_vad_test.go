@@ -0,0 +1,75 @@
+//go:build silero
+
+package silero
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chriscow/livekit-agents-go/pkg/ai/vad"
+	"github.com/chriscow/livekit-agents-go/pkg/rtc"
+)
+
+// stubModelPath is a model with the inputs and outputs of Silero v5 whose
+// speech probability is the peak amplitude of each window, generated by
+// testdata/silero/gen_stub_model.go.
+const stubModelPath = "../../../testdata/silero/stub_model.onnx"
+
+func TestSileroVAD_StubModel(t *testing.T) {
+	if err := ensureOrtEnv(); err != nil {
+		t.Skipf("Skipping, ONNX runtime not available: %v", err)
+	}
+
+	s, err := NewSileroVAD(Config{
+		ModelPath:          stubModelPath,
+		MinSpeechDuration:  64 * time.Millisecond,
+		MinSilenceDuration: 96 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to create Silero VAD: %v", err)
+	}
+	if !s.useONNX {
+		t.Fatal("expected the stub model to load")
+	}
+
+	// 200ms of loud audio followed by 200ms of silence
+	frames := make(chan rtc.AudioFrame, 40)
+	for i := 0; i < 40; i++ {
+		level := int16(0)
+		if i < 20 {
+			level = 20000
+		}
+		frames <- pcmFrame(level)
+	}
+	close(frames)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, err := s.Detect(ctx, frames)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+
+	var types []vad.VADEventType
+	for event := range events {
+		if event.Type == vad.VADEventError {
+			t.Fatalf("unexpected error event: %v", event.Error)
+		}
+		if event.Type == vad.VADEventInferenceDone {
+			continue
+		}
+		types = append(types, event.Type)
+	}
+	if len(types) != 2 || types[0] != vad.VADEventSpeechStart || types[1] != vad.VADEventSpeechEnd {
+		t.Errorf("expected one speech segment, got %v", types)
+	}
+}
+
+func TestONNXModel_ClosePartial(t *testing.T) {
+	// newONNXModel closes a model whose tensors are only partly created
+	m := &onnxModel{}
+	if err := m.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
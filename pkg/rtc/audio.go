@@ -5,15 +5,16 @@ import (
 	"time"
 )
 
-// AudioFrame represents exactly 10 ms of PCM audio.
+// AudioFrame represents 10 ms of PCM audio, or less in the final frame
+// flushed from a stream.
 // Len(Data) == SamplesPerChannel * NumChannels * 2.
 // All fields are immutable after creation except Data when processed in-place.
 //
 // A zero Timestamp means "live"; otherwise it points to absolute wall-clock.
 type AudioFrame struct {
 	Data              []byte        // 16-bit PCM, little-endian
-	SampleRate        int           // 48 000 or 16 000
-	SamplesPerChannel int           // SampleRate / 100
+	SampleRate        int           // e.g. 48 000, 24 000 or 16 000
+	SamplesPerChannel int           // SampleRate / 100, fewer in a flushed final frame
 	NumChannels       int           // 1 or 2
 	Timestamp         time.Duration // optional
 }
@@ -53,7 +54,12 @@ func (f *AudioFrame) Clone() *AudioFrame {
 	}
 }
 
-// Duration returns the duration represented by this frame (always 10ms).
+// Duration returns the duration of the audio in Data, or 0 if the frame's
+// sample rate or channel count is not set.
 func (f *AudioFrame) Duration() time.Duration {
-	return 10 * time.Millisecond
+	if f.SampleRate <= 0 || f.NumChannels <= 0 {
+		return 0
+	}
+	samples := len(f.Data) / (2 * f.NumChannels)
+	return time.Duration(samples) * time.Second / time.Duration(f.SampleRate)
 }
\ No newline at end of file
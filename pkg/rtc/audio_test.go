@@ -129,11 +129,19 @@ func TestAudioFrameClone(t *testing.T) {
 }
 
 func TestAudioFrameDuration(t *testing.T) {
-	frame := &AudioFrame{}
-	duration := frame.Duration()
-	expected := 10 * time.Millisecond
+	tests := []struct {
+		frame    AudioFrame
+		expected time.Duration
+	}{
+		{AudioFrame{Data: make([]byte, 960), SampleRate: 48000, NumChannels: 1}, 10 * time.Millisecond},
+		{AudioFrame{Data: make([]byte, 1920), SampleRate: 48000, NumChannels: 2}, 10 * time.Millisecond},
+		{AudioFrame{Data: make([]byte, 240), SampleRate: 24000, NumChannels: 1}, 5 * time.Millisecond}, // flushed partial frame
+		{AudioFrame{}, 0},
+	}
 
-	if duration != expected {
-		t.Errorf("Duration() = %v, want %v", duration, expected)
+	for _, tt := range tests {
+		if duration := tt.frame.Duration(); duration != tt.expected {
+			t.Errorf("Duration() of %d bytes at %d Hz, %d channels = %v, want %v", len(tt.frame.Data), tt.frame.SampleRate, tt.frame.NumChannels, duration, tt.expected)
+		}
 	}
 }
\ No newline at end of file
@@ -0,0 +1,85 @@
+package rtc
+
+import (
+	"fmt"
+	"time"
+)
+
+// AudioByteStream splits 16-bit PCM bytes written in chunks of any size into
+// 10 ms frames. Frame timestamps are the stream position of their first
+// sample, starting at zero and increasing by the duration of each frame.
+//
+// An AudioByteStream is not safe for concurrent use.
+type AudioByteStream struct {
+	sampleRate  int
+	numChannels int
+	frameSize   int // bytes in a 10 ms frame
+	buf         []byte
+	samples     int64 // samples per channel in the frames returned so far
+}
+
+// NewAudioByteStream creates a stream producing frames of the given format.
+// The sample rate must be a multiple of 100 so that a frame holds exactly 10 ms.
+func NewAudioByteStream(sampleRate, numChannels int) (*AudioByteStream, error) {
+	if sampleRate <= 0 || sampleRate%100 != 0 {
+		return nil, fmt.Errorf("sample rate %d does not divide into 10ms frames", sampleRate)
+	}
+	if numChannels <= 0 {
+		return nil, fmt.Errorf("invalid channel count %d", numChannels)
+	}
+	return &AudioByteStream{
+		sampleRate:  sampleRate,
+		numChannels: numChannels,
+		frameSize:   sampleRate / 100 * numChannels * 2,
+	}, nil
+}
+
+// Write appends PCM bytes and returns the complete frames now available.
+// Bytes that do not fill a frame are kept for the next Write or Flush.
+func (s *AudioByteStream) Write(data []byte) []AudioFrame {
+	s.buf = append(s.buf, data...)
+
+	var frames []AudioFrame
+	consumed := 0
+	for ; len(s.buf)-consumed >= s.frameSize; consumed += s.frameSize {
+		frames = append(frames, s.frame(s.buf[consumed:consumed+s.frameSize]))
+	}
+	s.buf = append(s.buf[:0], s.buf[consumed:]...)
+	return frames
+}
+
+// Flush returns the buffered bytes as a final frame, or nil if nothing is
+// buffered. With pad the frame is filled with silence to 10 ms, otherwise it
+// is a shorter partial frame. An incomplete trailing sample is discarded.
+func (s *AudioByteStream) Flush(pad bool) []AudioFrame {
+	sampleSize := 2 * s.numChannels
+	data := s.buf[:len(s.buf)/sampleSize*sampleSize]
+	s.buf = s.buf[:0]
+	if len(data) == 0 {
+		return nil
+	}
+
+	if pad {
+		data = append(data, make([]byte, s.frameSize-len(data))...)
+	}
+	return []AudioFrame{s.frame(data)}
+}
+
+// Buffered returns the number of bytes waiting for a complete frame.
+func (s *AudioByteStream) Buffered() int {
+	return len(s.buf)
+}
+
+// frame copies data into a frame at the current position and advances it.
+func (s *AudioByteStream) frame(data []byte) AudioFrame {
+	samples := len(data) / (2 * s.numChannels)
+	frame := AudioFrame{
+		Data:              append([]byte(nil), data...),
+		SampleRate:        s.sampleRate,
+		SamplesPerChannel: samples,
+		NumChannels:       s.numChannels,
+		Timestamp:         time.Duration(s.samples) * time.Second / time.Duration(s.sampleRate),
+	}
+	s.samples += int64(samples)
+	return frame
+}
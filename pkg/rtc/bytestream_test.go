@@ -0,0 +1,110 @@
+package rtc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAudioByteStream_Write(t *testing.T) {
+	stream, err := NewAudioByteStream(24000, 1)
+	if err != nil {
+		t.Fatalf("NewAudioByteStream() error = %v", err)
+	}
+
+	// 24 kHz mono frames are 480 bytes, write in chunks that do not line up
+	var frames []AudioFrame
+	for _, size := range []int{100, 2048, 1, 331} {
+		data := make([]byte, size)
+		frames = append(frames, stream.Write(data)...)
+	}
+
+	// 2480 bytes make 5 frames with 80 bytes left over
+	if len(frames) != 5 {
+		t.Fatalf("expected 5 frames, got %d", len(frames))
+	}
+	for i, frame := range frames {
+		if len(frame.Data) != 480 || frame.SamplesPerChannel != 240 || frame.SampleRate != 24000 || frame.NumChannels != 1 {
+			t.Errorf("frame %d is not a 10 ms frame: %d bytes, %d samples", i, len(frame.Data), frame.SamplesPerChannel)
+		}
+		if want := time.Duration(i) * 10 * time.Millisecond; frame.Timestamp != want {
+			t.Errorf("frame %d timestamp = %v, want %v", i, frame.Timestamp, want)
+		}
+	}
+	if stream.Buffered() != 80 {
+		t.Errorf("expected 80 bytes buffered, got %d", stream.Buffered())
+	}
+}
+
+func TestAudioByteStream_Flush(t *testing.T) {
+	tests := []struct {
+		name        string
+		pad         bool
+		wantBytes   int
+		wantSamples int
+	}{
+		{"partial", false, 200, 50},
+		{"padded", true, 640, 160},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, _ := NewAudioByteStream(16000, 2)
+
+			// One full frame and 50 stereo samples plus half a sample
+			data := make([]byte, 640+200+2)
+			for i := range data {
+				data[i] = 1
+			}
+			frames := stream.Write(data)
+			if len(frames) != 1 {
+				t.Fatalf("expected 1 frame from Write, got %d", len(frames))
+			}
+
+			flushed := stream.Flush(tt.pad)
+			if len(flushed) != 1 {
+				t.Fatalf("expected 1 frame from Flush, got %d", len(flushed))
+			}
+			frame := flushed[0]
+			if len(frame.Data) != tt.wantBytes || frame.SamplesPerChannel != tt.wantSamples {
+				t.Errorf("flushed frame has %d bytes and %d samples, want %d and %d", len(frame.Data), frame.SamplesPerChannel, tt.wantBytes, tt.wantSamples)
+			}
+			if frame.Data[199] != 1 || (tt.pad && frame.Data[200] != 0) {
+				t.Error("expected the buffered audio followed by silence")
+			}
+			if frame.Timestamp != 10*time.Millisecond {
+				t.Errorf("flushed frame timestamp = %v, want 10ms", frame.Timestamp)
+			}
+
+			// Timestamps keep increasing after a flush
+			next := stream.Write(make([]byte, 640))
+			want := 10*time.Millisecond + time.Duration(tt.wantSamples)*time.Second/16000
+			if len(next) != 1 || next[0].Timestamp != want {
+				t.Errorf("expected the next frame at %v, got %+v", want, next)
+			}
+		})
+	}
+
+	stream, _ := NewAudioByteStream(48000, 1)
+	if frames := stream.Flush(true); frames != nil {
+		t.Errorf("expected no frame from an empty stream, got %d", len(frames))
+	}
+}
+
+func TestAudioByteStream_CopiesData(t *testing.T) {
+	stream, _ := NewAudioByteStream(16000, 1)
+	data := make([]byte, 320)
+	frames := stream.Write(data)
+	data[0] = 42
+	if frames[0].Data[0] != 0 {
+		t.Error("frame data should not alias the written slice")
+	}
+}
+
+func TestNewAudioByteStream_Invalid(t *testing.T) {
+	if _, err := NewAudioByteStream(22050, 1); err == nil {
+		t.Error("expected an error for a rate that does not divide into 10ms frames")
+	}
+	if _, err := NewAudioByteStream(16000, 0); err == nil {
+		t.Error("expected an error for zero channels")
+	}
+}
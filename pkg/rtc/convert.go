@@ -0,0 +1,169 @@
+package rtc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AudioConverter converts frames of any sample rate, channel count and length
+// to 10 ms frames of a target format. Audio that does not fill a whole frame is
+// held until the next Push or Flush.
+//
+// An AudioConverter is not safe for concurrent use.
+type AudioConverter struct {
+	sampleRate  int
+	numChannels int
+
+	inRate     int
+	inChannels int
+	resampler  *Resampler       // nil when the input rate matches the target
+	pending    *AudioByteStream // converted audio not yet framed
+
+	started   bool
+	timestamp time.Duration // timestamp of the next output frame, zero for live audio
+}
+
+// NewAudioConverter creates a converter producing frames at sampleRate with
+// numChannels channels. The sample rate must be a multiple of 100 so that a
+// frame holds exactly 10 ms.
+func NewAudioConverter(sampleRate, numChannels int) (*AudioConverter, error) {
+	pending, err := NewAudioByteStream(sampleRate, numChannels)
+	if err != nil {
+		return nil, err
+	}
+	return &AudioConverter{sampleRate: sampleRate, numChannels: numChannels, pending: pending}, nil
+}
+
+// Push converts a frame and returns the complete 10 ms frames now available.
+// A frame already in the target format is returned as is when no audio is
+// pending.
+func (c *AudioConverter) Push(frame AudioFrame) ([]AudioFrame, error) {
+	if frame.SampleRate <= 0 || frame.NumChannels <= 0 {
+		return nil, fmt.Errorf("invalid frame format: %d Hz, %d channels", frame.SampleRate, frame.NumChannels)
+	}
+	if !c.started {
+		c.started = true
+		c.timestamp = frame.Timestamp
+	}
+
+	var frames []AudioFrame
+	if frame.SampleRate != c.inRate || frame.NumChannels != c.inChannels {
+		var err error
+		if frames, err = c.setInputFormat(frame.SampleRate, frame.NumChannels); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.resampler == nil && c.pending.Buffered() == 0 && frame.NumChannels == c.numChannels && len(frame.Data) == 2*c.frameSamples() {
+		c.advance()
+		return append(frames, frame), nil
+	}
+
+	samples, err := ConvertChannels(DecodePCM(frame.Data), frame.NumChannels, c.numChannels)
+	if err != nil {
+		return nil, err
+	}
+	if c.resampler != nil {
+		samples = c.resampler.Push(samples)
+	}
+	return append(frames, c.stamp(c.pending.Write(EncodePCM(samples)))...), nil
+}
+
+// Flush returns the remaining audio, padded with silence to a whole frame.
+func (c *AudioConverter) Flush() []AudioFrame {
+	var frames []AudioFrame
+	if c.resampler != nil {
+		frames = c.pending.Write(EncodePCM(c.resampler.Flush()))
+	}
+	return c.stamp(append(frames, c.pending.Flush(true)...))
+}
+
+// setInputFormat switches to a new input format. Audio still buffered in the
+// resampler for the previous format is flushed, and the frames it completes are
+// returned.
+func (c *AudioConverter) setInputFormat(sampleRate, numChannels int) ([]AudioFrame, error) {
+	var frames []AudioFrame
+	if c.resampler != nil {
+		frames = c.stamp(c.pending.Write(EncodePCM(c.resampler.Flush())))
+		c.resampler = nil
+	}
+	if sampleRate != c.sampleRate {
+		resampler, err := NewResampler(sampleRate, c.sampleRate, c.numChannels)
+		if err != nil {
+			return nil, err
+		}
+		c.resampler = resampler
+	}
+	c.inRate = sampleRate
+	c.inChannels = numChannels
+	return frames, nil
+}
+
+// stamp sets the timestamps of converted frames to follow the input's.
+func (c *AudioConverter) stamp(frames []AudioFrame) []AudioFrame {
+	for i := range frames {
+		frames[i].Timestamp = c.timestamp
+		c.advance()
+	}
+	return frames
+}
+
+// advance moves the output timestamp past one frame. Live audio keeps a zero
+// timestamp.
+func (c *AudioConverter) advance() {
+	if c.timestamp != 0 {
+		c.timestamp += 10 * time.Millisecond
+	}
+}
+
+// frameSamples returns the number of interleaved samples in a 10 ms frame.
+func (c *AudioConverter) frameSamples() int {
+	return c.sampleRate / 100 * c.numChannels
+}
+
+// ConvertAudio returns a channel of 10 ms frames in the given format converted
+// from in. Frames already in that format pass through unchanged and frames
+// with an invalid format are dropped. The output is flushed and closed when
+// in is closed, or closed when ctx is done.
+func ConvertAudio(ctx context.Context, in <-chan AudioFrame, sampleRate, numChannels int) (<-chan AudioFrame, error) {
+	converter, err := NewAudioConverter(sampleRate, numChannels)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan AudioFrame, cap(in))
+	go func() {
+		defer close(out)
+		send := func(frames []AudioFrame) bool {
+			for _, frame := range frames {
+				select {
+				case out <- frame:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			return true
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case frame, ok := <-in:
+				if !ok {
+					send(converter.Flush())
+					return
+				}
+				frames, err := converter.Push(frame)
+				if err != nil {
+					continue
+				}
+				if !send(frames) {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
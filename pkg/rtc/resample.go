@@ -0,0 +1,245 @@
+package rtc
+
+import (
+	"fmt"
+	"math"
+)
+
+// resamplerZeroCrossings is the number of sinc zero crossings on each side of
+// the filter. More crossings give a sharper cutoff at a higher cost.
+const resamplerZeroCrossings = 16
+
+// resamplerRolloff places the cutoff slightly below the Nyquist frequency of
+// the lower rate so that the transition band does not alias.
+const resamplerRolloff = 0.94
+
+// Resampler converts interleaved 16-bit PCM between sample rates with a
+// polyphase windowed-sinc filter. It keeps the filter history between calls,
+// so a stream can be resampled in chunks of any size without discontinuities.
+// The output lags the input by half the filter length until Flush is called.
+//
+// A Resampler is not safe for concurrent use.
+type Resampler struct {
+	inRate      int
+	outRate     int
+	numChannels int
+	up, down    int         // outRate/inRate reduced to lowest terms
+	halfWidth   int         // filter taps on each side of the center
+	filters     [][]float64 // one filter per phase, 2*halfWidth taps each
+
+	history  [][]float64 // buffered input per channel
+	base     int64       // input index of history[c][0]
+	consumed int64       // input samples per channel pushed so far
+	next     int64       // index of the next output sample
+}
+
+// NewResampler creates a resampler from inRate to outRate for audio with the
+// given number of interleaved channels.
+func NewResampler(inRate, outRate, numChannels int) (*Resampler, error) {
+	if inRate <= 0 || outRate <= 0 {
+		return nil, fmt.Errorf("invalid sample rates %d -> %d", inRate, outRate)
+	}
+	if numChannels <= 0 {
+		return nil, fmt.Errorf("invalid channel count %d", numChannels)
+	}
+
+	g := gcd(inRate, outRate)
+	up, down := outRate/g, inRate/g
+
+	// Cutoff in cycles per input sample, lowered when downsampling
+	cutoff := 0.5 * resamplerRolloff
+	if outRate < inRate {
+		cutoff *= float64(outRate) / float64(inRate)
+	}
+	halfWidth := int(math.Ceil(resamplerZeroCrossings / (2 * cutoff)))
+
+	filters := make([][]float64, up)
+	for p := range filters {
+		frac := float64(p) / float64(up)
+		taps := make([]float64, 2*halfWidth)
+		var sum float64
+		for k := range taps {
+			x := float64(k-halfWidth+1) - frac
+			taps[k] = 2 * cutoff * sinc(2*cutoff*x) * blackman(x, float64(halfWidth))
+			sum += taps[k]
+		}
+		// Normalize each phase to unity gain at DC
+		for k := range taps {
+			taps[k] /= sum
+		}
+		filters[p] = taps
+	}
+
+	r := &Resampler{
+		inRate:      inRate,
+		outRate:     outRate,
+		numChannels: numChannels,
+		up:          up,
+		down:        down,
+		halfWidth:   halfWidth,
+		filters:     filters,
+	}
+	r.Reset()
+	return r, nil
+}
+
+// Reset discards the buffered input so the resampler can start a new stream.
+func (r *Resampler) Reset() {
+	r.history = make([][]float64, r.numChannels)
+	for c := range r.history {
+		// Samples before the start of the stream are silence
+		r.history[c] = make([]float64, r.halfWidth-1)
+	}
+	r.base = -int64(r.halfWidth - 1)
+	r.consumed = 0
+	r.next = 0
+}
+
+// Push resamples interleaved samples and returns the interleaved output that
+// is ready. A trailing partial sample frame is ignored.
+func (r *Resampler) Push(samples []int16) []int16 {
+	frames := len(samples) / r.numChannels
+	for c := range r.history {
+		for i := 0; i < frames; i++ {
+			r.history[c] = append(r.history[c], float64(samples[i*r.numChannels+c]))
+		}
+	}
+	r.consumed += int64(frames)
+	return r.process(math.MaxInt64)
+}
+
+// Flush returns the remaining output of the stream and resets the resampler.
+func (r *Resampler) Flush() []int16 {
+	for c := range r.history {
+		r.history[c] = append(r.history[c], make([]float64, r.halfWidth)...)
+	}
+	// The output covers exactly the duration of the input
+	end := (r.consumed*int64(r.up) + int64(r.down) - 1) / int64(r.down)
+	out := r.process(end)
+	r.Reset()
+	return out
+}
+
+// process computes output samples up to index end for which the filter has
+// all of its input, then drops input that is no longer needed.
+func (r *Resampler) process(end int64) []int16 {
+	up, down, width := int64(r.up), int64(r.down), int64(r.halfWidth)
+	available := r.base + int64(len(r.history[0]))
+
+	var out []int16
+	for ; r.next < end; r.next++ {
+		position := r.next * down
+		center := position / up
+		if center+width >= available {
+			break
+		}
+		taps := r.filters[position%up]
+		start := center - width + 1 - r.base
+		for c := range r.history {
+			input := r.history[c][start : start+2*width]
+			var sum float64
+			for k, tap := range taps {
+				sum += input[k] * tap
+			}
+			out = append(out, clampInt16(sum))
+		}
+	}
+
+	// Keep the input still needed by the next output sample
+	drop := r.next*down/up - width + 1 - r.base
+	if drop > 0 {
+		for c := range r.history {
+			r.history[c] = append(r.history[c][:0], r.history[c][drop:]...)
+		}
+		r.base += drop
+	}
+	return out
+}
+
+// ConvertChannels converts interleaved samples between channel counts. Mono is
+// copied to every output channel and multi-channel audio is averaged to mono.
+func ConvertChannels(samples []int16, from, to int) ([]int16, error) {
+	if from <= 0 || to <= 0 {
+		return nil, fmt.Errorf("invalid channel conversion %d -> %d", from, to)
+	}
+	if from == to {
+		return samples, nil
+	}
+
+	frames := len(samples) / from
+	switch {
+	case to == 1:
+		out := make([]int16, frames)
+		for i := range out {
+			var sum int
+			for c := 0; c < from; c++ {
+				sum += int(samples[i*from+c])
+			}
+			out[i] = int16(sum / from)
+		}
+		return out, nil
+	case from == 1:
+		out := make([]int16, frames*to)
+		for i := 0; i < frames; i++ {
+			for c := 0; c < to; c++ {
+				out[i*to+c] = samples[i]
+			}
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported channel conversion %d -> %d", from, to)
+	}
+}
+
+// DecodePCM converts 16-bit little-endian PCM bytes to samples.
+func DecodePCM(data []byte) []int16 {
+	samples := make([]int16, len(data)/2)
+	for i := range samples {
+		samples[i] = int16(data[2*i]) | int16(data[2*i+1])<<8
+	}
+	return samples
+}
+
+// EncodePCM converts samples to 16-bit little-endian PCM bytes.
+func EncodePCM(samples []int16) []byte {
+	data := make([]byte, 2*len(samples))
+	for i, s := range samples {
+		data[2*i] = byte(s)
+		data[2*i+1] = byte(s >> 8)
+	}
+	return data
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// blackman evaluates a Blackman window of the given half width at x.
+func blackman(x, halfWidth float64) float64 {
+	if math.Abs(x) > halfWidth {
+		return 0
+	}
+	t := math.Pi * x / halfWidth
+	return 0.42 + 0.5*math.Cos(t) + 0.08*math.Cos(2*t)
+}
+
+func clampInt16(v float64) int16 {
+	v = math.Round(v)
+	if v > math.MaxInt16 {
+		return math.MaxInt16
+	}
+	if v < math.MinInt16 {
+		return math.MinInt16
+	}
+	return int16(v)
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
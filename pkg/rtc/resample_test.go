@@ -0,0 +1,299 @@
+package rtc
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// sine generates a tone of freq Hz at the given sample rate and amplitude.
+func sine(freq float64, sampleRate, n int, amplitude float64) []int16 {
+	samples := make([]int16, n)
+	for i := range samples {
+		samples[i] = int16(amplitude * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)))
+	}
+	return samples
+}
+
+func rms(samples []int16) float64 {
+	var sum float64
+	for _, s := range samples {
+		sum += float64(s) * float64(s)
+	}
+	return math.Sqrt(sum / float64(len(samples)))
+}
+
+func TestResampler_PreservesTone(t *testing.T) {
+	tests := []struct {
+		inRate, outRate int
+	}{
+		{48000, 16000},
+		{16000, 48000},
+		{24000, 48000},
+		{44100, 48000},
+	}
+
+	for _, tt := range tests {
+		r, err := NewResampler(tt.inRate, tt.outRate, 1)
+		if err != nil {
+			t.Fatalf("NewResampler(%d, %d) error = %v", tt.inRate, tt.outRate, err)
+		}
+		out := append(r.Push(sine(1000, tt.inRate, tt.inRate/10, 10000)), r.Flush()...)
+
+		if want := tt.outRate / 10; len(out) != want {
+			t.Errorf("%d -> %d: got %d samples, want %d", tt.inRate, tt.outRate, len(out), want)
+			continue
+		}
+
+		// Away from the edges the output matches the ideal tone at the new rate
+		want := sine(1000, tt.outRate, len(out), 10000)
+		edge := len(out) / 10
+		var errSum float64
+		for i := edge; i < len(out)-edge; i++ {
+			d := float64(out[i]) - float64(want[i])
+			errSum += d * d
+		}
+		if errRMS := math.Sqrt(errSum / float64(len(out)-2*edge)); errRMS > 50 {
+			t.Errorf("%d -> %d: RMS error %.1f against the ideal tone", tt.inRate, tt.outRate, errRMS)
+		}
+	}
+}
+
+func TestResampler_FiltersAliasing(t *testing.T) {
+	r, err := NewResampler(48000, 16000, 1)
+	if err != nil {
+		t.Fatalf("NewResampler() error = %v", err)
+	}
+
+	// 12 kHz is above the 8 kHz Nyquist frequency of the output
+	out := r.Push(sine(12000, 48000, 4800, 10000))
+	if level := rms(out[len(out)/4:]); level > 100 {
+		t.Errorf("expected a 12 kHz tone to be filtered out, got RMS %.1f", level)
+	}
+}
+
+func TestResampler_Chunked(t *testing.T) {
+	input := sine(440, 24000, 2400, 8000)
+
+	whole, _ := NewResampler(24000, 48000, 1)
+	want := append(whole.Push(input), whole.Flush()...)
+
+	chunked, _ := NewResampler(24000, 48000, 1)
+	var got []int16
+	for start := 0; start < len(input); start += 237 {
+		end := min(start+237, len(input))
+		got = append(got, chunked.Push(input[start:end])...)
+	}
+	got = append(got, chunked.Flush()...)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("chunked output differs from resampling in one call (%d vs %d samples)", len(got), len(want))
+	}
+}
+
+func TestResampler_Stereo(t *testing.T) {
+	left := sine(500, 48000, 480, 10000)
+	stereo := make([]int16, 2*len(left))
+	for i, s := range left {
+		stereo[2*i] = s
+		stereo[2*i+1] = -s
+	}
+
+	r, _ := NewResampler(48000, 16000, 2)
+	out := append(r.Push(stereo), r.Flush()...)
+	if len(out) != 320 {
+		t.Fatalf("expected 160 stereo samples, got %d values", len(out))
+	}
+	for i := 0; i < len(out); i += 2 {
+		if out[i] != -out[i+1] {
+			t.Fatalf("channels mixed at sample %d: %d, %d", i/2, out[i], out[i+1])
+		}
+	}
+}
+
+func TestNewResampler_Invalid(t *testing.T) {
+	if _, err := NewResampler(0, 16000, 1); err == nil {
+		t.Error("expected an error for a zero input rate")
+	}
+	if _, err := NewResampler(48000, 16000, 0); err == nil {
+		t.Error("expected an error for zero channels")
+	}
+}
+
+func TestConvertChannels(t *testing.T) {
+	tests := []struct {
+		name     string
+		samples  []int16
+		from, to int
+		want     []int16
+	}{
+		{"mono to stereo", []int16{1, -2}, 1, 2, []int16{1, 1, -2, -2}},
+		{"stereo to mono", []int16{10, 20, -4, 0}, 2, 1, []int16{15, -2}},
+		{"unchanged", []int16{1, 2}, 2, 2, []int16{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertChannels(tt.samples, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("ConvertChannels() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertChannels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ConvertChannels([]int16{1, 2, 3, 4, 5, 6}, 3, 2); err == nil {
+		t.Error("expected an error for 3 -> 2 channels")
+	}
+}
+
+func TestPCMRoundTrip(t *testing.T) {
+	samples := []int16{0, 1, -1, math.MaxInt16, math.MinInt16}
+	if got := DecodePCM(EncodePCM(samples)); !reflect.DeepEqual(got, samples) {
+		t.Errorf("round trip = %v, want %v", got, samples)
+	}
+}
+
+func TestAudioConverter_Reframes(t *testing.T) {
+	converter, err := NewAudioConverter(48000, 1)
+	if err != nil {
+		t.Fatalf("NewAudioConverter() error = %v", err)
+	}
+
+	// 24 kHz audio in 1024-sample chunks, as a TTS service might deliver it
+	input := sine(440, 24000, 4*1024, 8000)
+	var frames []AudioFrame
+	for i := 0; i < 4; i++ {
+		chunk := input[i*1024 : (i+1)*1024]
+		out, err := converter.Push(AudioFrame{
+			Data:              EncodePCM(chunk),
+			SampleRate:        24000,
+			SamplesPerChannel: len(chunk),
+			NumChannels:       1,
+			Timestamp:         time.Second,
+		})
+		if err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+		frames = append(frames, out...)
+	}
+	frames = append(frames, converter.Flush()...)
+
+	// 4096 samples at 24 kHz are 170.67 ms, padded to 18 frames
+	if len(frames) != 18 {
+		t.Fatalf("expected 18 frames, got %d", len(frames))
+	}
+	for i, frame := range frames {
+		if frame.SampleRate != 48000 || frame.NumChannels != 1 || frame.SamplesPerChannel != 480 || len(frame.Data) != 960 {
+			t.Fatalf("frame %d has the wrong format: %d Hz, %d channels, %d bytes", i, frame.SampleRate, frame.NumChannels, len(frame.Data))
+		}
+		if want := time.Second + time.Duration(i)*10*time.Millisecond; frame.Timestamp != want {
+			t.Errorf("frame %d timestamp = %v, want %v", i, frame.Timestamp, want)
+		}
+	}
+}
+
+func TestAudioConverter_Passthrough(t *testing.T) {
+	converter, _ := NewAudioConverter(16000, 1)
+	frame, _ := NewAudioFrame(EncodePCM(sine(440, 16000, 160, 8000)), 16000, 1, 0)
+
+	out, err := converter.Push(*frame)
+	if err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if len(out) != 1 || &out[0].Data[0] != &frame.Data[0] {
+		t.Errorf("expected a frame in the target format to pass through unchanged")
+	}
+	if rest := converter.Flush(); len(rest) != 0 {
+		t.Errorf("expected nothing pending, got %d frames", len(rest))
+	}
+}
+
+func TestAudioConverter_StereoDownsample(t *testing.T) {
+	converter, _ := NewAudioConverter(16000, 1)
+	frame, _ := NewAudioFrame(make([]byte, 1920), 48000, 2, 0)
+
+	var frames []AudioFrame
+	for i := 0; i < 10; i++ {
+		out, err := converter.Push(*frame)
+		if err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+		frames = append(frames, out...)
+	}
+	frames = append(frames, converter.Flush()...)
+
+	if len(frames) != 10 {
+		t.Fatalf("expected 10 frames for 100 ms of audio, got %d", len(frames))
+	}
+	for _, f := range frames {
+		if len(f.Data) != 320 || f.NumChannels != 1 || f.Timestamp != 0 {
+			t.Fatalf("unexpected frame: %d bytes, %d channels, timestamp %v", len(f.Data), f.NumChannels, f.Timestamp)
+		}
+	}
+}
+
+func TestAudioConverter_InputRateChange(t *testing.T) {
+	converter, _ := NewAudioConverter(48000, 1)
+
+	// 100 ms at 24 kHz followed by 100 ms at 16 kHz
+	var frames []AudioFrame
+	for _, rate := range []int{24000, 16000} {
+		for i := 0; i < 10; i++ {
+			frame, _ := NewAudioFrame(EncodePCM(sine(440, rate, rate/100, 8000)), rate, 1, time.Second)
+			out, err := converter.Push(*frame)
+			if err != nil {
+				t.Fatalf("Push() error = %v", err)
+			}
+			frames = append(frames, out...)
+		}
+	}
+	frames = append(frames, converter.Flush()...)
+
+	var samples int
+	for i, frame := range frames {
+		samples += len(frame.Data) / 2
+		if want := time.Second + time.Duration(i)*10*time.Millisecond; frame.Timestamp != want {
+			t.Errorf("frame %d timestamp = %v, want %v", i, frame.Timestamp, want)
+		}
+	}
+	if duration := time.Duration(samples) * time.Second / 48000; duration != 200*time.Millisecond {
+		t.Errorf("expected 200 ms of audio, got %v in %d frames", duration, len(frames))
+	}
+}
+
+func TestNewAudioConverter_Invalid(t *testing.T) {
+	if _, err := NewAudioConverter(22050, 1); err == nil {
+		t.Error("expected an error for a rate that does not divide into 10ms frames")
+	}
+}
+
+func TestConvertAudio(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan AudioFrame, 5)
+	for i := 0; i < 5; i++ {
+		frame, _ := NewAudioFrame(make([]byte, 960), 48000, 1, 0)
+		in <- *frame
+	}
+	close(in)
+
+	out, err := ConvertAudio(ctx, in, 16000, 1)
+	if err != nil {
+		t.Fatalf("ConvertAudio() error = %v", err)
+	}
+	count := 0
+	for frame := range out {
+		if frame.SampleRate != 16000 || len(frame.Data) != 320 {
+			t.Errorf("unexpected frame: %d Hz, %d bytes", frame.SampleRate, len(frame.Data))
+		}
+		count++
+	}
+	if count != 5 {
+		t.Errorf("expected 5 frames, got %d", count)
+	}
+}
@@ -0,0 +1,111 @@
+//go:build ignore
+
+// gen_stub_model writes stub_model.onnx, a tiny model with the inputs and
+// outputs of Silero VAD v5 for tests of the silero plugin:
+//
+//	output = ReduceMax(Abs(input), axes=[1])  // peak amplitude as speech probability
+//	stateN = state                            // state is passed through
+//
+// Run it from the repository root with:
+//
+//	go run testdata/silero/gen_stub_model.go
+package main
+
+import (
+	"encoding/binary"
+	"log"
+	"os"
+)
+
+// ONNX protobuf element types
+const (
+	elemFloat = 1
+	elemInt64 = 7
+)
+
+func main() {
+	graph := cat(
+		field(1, node("abs", "Abs", []string{"input"}, []string{"magnitude"})),
+		field(1, node("reduce_max", "ReduceMax", []string{"magnitude"}, []string{"output"},
+			attrInts("axes", 1), attrInt("keepdims", 1))),
+		field(1, node("pass_state", "Identity", []string{"state"}, []string{"stateN"})),
+		field(2, []byte("silero_stub")),
+		field(11, valueInfo("input", elemFloat, -1, -1)),
+		field(11, valueInfo("state", elemFloat, 2, 1, 128)),
+		field(11, valueInfo("sr", elemInt64)),
+		field(12, valueInfo("output", elemFloat, -1, 1)),
+		field(12, valueInfo("stateN", elemFloat, 2, 1, 128)),
+	)
+	model := cat(
+		varintField(1, 8), // IR version
+		field(2, []byte("livekit-agents-go")),
+		field(7, graph),
+		field(8, cat(field(1, nil), varintField(2, 13))), // default opset 13
+	)
+	if err := os.WriteFile("testdata/silero/stub_model.onnx", model, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// node encodes a NodeProto.
+func node(name, op string, inputs, outputs []string, attrs ...[]byte) []byte {
+	var b []byte
+	for _, in := range inputs {
+		b = append(b, field(1, []byte(in))...)
+	}
+	for _, out := range outputs {
+		b = append(b, field(2, []byte(out))...)
+	}
+	b = append(b, field(3, []byte(name))...)
+	b = append(b, field(4, []byte(op))...)
+	for _, attr := range attrs {
+		b = append(b, field(5, attr)...)
+	}
+	return b
+}
+
+// attrInt encodes an integer AttributeProto.
+func attrInt(name string, value int64) []byte {
+	return cat(field(1, []byte(name)), varintField(3, uint64(value)), varintField(20, 2))
+}
+
+// attrInts encodes an integer list AttributeProto.
+func attrInts(name string, values ...int64) []byte {
+	b := field(1, []byte(name))
+	for _, v := range values {
+		b = append(b, varintField(8, uint64(v))...)
+	}
+	return append(b, varintField(20, 7)...)
+}
+
+// valueInfo encodes a tensor ValueInfoProto. Negative dimensions are dynamic.
+func valueInfo(name string, elem int, dims ...int64) []byte {
+	var shape []byte
+	for i, d := range dims {
+		if d < 0 {
+			shape = append(shape, field(1, field(2, []byte{'d', byte('0' + i)}))...)
+		} else {
+			shape = append(shape, field(1, varintField(1, uint64(d)))...)
+		}
+	}
+	tensorType := cat(varintField(1, uint64(elem)), field(2, shape))
+	return cat(field(1, []byte(name)), field(2, field(1, tensorType)))
+}
+
+func field(num int, data []byte) []byte {
+	b := binary.AppendUvarint(nil, uint64(num<<3|2))
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+func varintField(num int, value uint64) []byte {
+	return binary.AppendUvarint(binary.AppendUvarint(nil, uint64(num<<3)), value)
+}
+
+func cat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}